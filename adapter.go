@@ -0,0 +1,78 @@
+package echonext
+
+import (
+	"reflect"
+	"sync"
+)
+
+// handlerAdapter is a typed handler analyzed once at registration, so each
+// request only does the reflection it cannot avoid: allocating the request
+// (reused through a pool) and calling the handler.
+type handlerAdapter struct {
+	fn          reflect.Value
+	context     contextBuilder // Builds the AppContext the handler takes; nil for echo.Context
+	requests    *sync.Pool     // Pointers to zeroed request structs; nil without a request
+	errorIndex  int            // Result holding the error, or -1
+	statusIndex int            // Result holding the status of (T, int, error) handlers, or -1
+	hasData     bool           // Whether the first result is data rather than the error
+}
+
+func newHandlerAdapter(handler interface{}, requestType reflect.Type) *handlerAdapter {
+	fn := reflect.ValueOf(handler)
+	fnType := fn.Type()
+	a := &handlerAdapter{fn: fn, errorIndex: -1, statusIndex: -1}
+	if fnType.NumIn() > 0 {
+		a.context = contextBuilderFor(fnType.In(0))
+	}
+
+	if requestType != nil {
+		a.requests = &sync.Pool{New: func() interface{} { return reflect.New(requestType).Interface() }}
+	}
+	if n := fnType.NumOut(); n > 0 {
+		if fnType.Out(n-1) == errorType {
+			a.errorIndex = n - 1
+		}
+		a.hasData = a.errorIndex != 0
+	}
+	if fnType.NumOut() == 3 {
+		a.statusIndex = 1
+	}
+	return a
+}
+
+// acquire returns a pointer to a zeroed request struct
+func (a *handlerAdapter) acquire() reflect.Value {
+	return reflect.ValueOf(a.requests.Get())
+}
+
+// release zeroes a request from acquire and returns it to the pool. The
+// handler received a copy, so nothing it kept refers to the pooled struct.
+func (a *handlerAdapter) release(req reflect.Value) {
+	req.Elem().SetZero()
+	a.requests.Put(req.Interface())
+}
+
+// call invokes the handler with ctx, its echo.Context or AppContext, and,
+// when valid, the request that req points to. It returns the data result
+// (invalid when the handler has none), the returned status (0 when not
+// returned) and the returned error.
+func (a *handlerAdapter) call(ctx reflect.Value, req reflect.Value) (data reflect.Value, status int, err error) {
+	args := [2]reflect.Value{ctx}
+	n := 1
+	if req.IsValid() {
+		args[1] = req.Elem()
+		n = 2
+	}
+
+	results := a.fn.Call(args[:n])
+	if a.errorIndex >= 0 {
+		err, _ = results[a.errorIndex].Interface().(error)
+	}
+	if a.statusIndex >= 0 {
+		status = int(results[a.statusIndex].Int())
+	}
+	if a.hasData {
+		data = results[0]
+	}
+	return data, status, err
+}
@@ -0,0 +1,148 @@
+package echonext
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetSecurityEnforcement turns on checking the credentials of routes with
+// security requirements. Without it Security only documents the route.
+// Requests lacking the credentials of every scheme a route accepts are
+// rejected with 401.
+func (app *App) SetSecurityEnforcement(enabled bool) {
+	app.enforceSecurity = enabled
+}
+
+// SetAuthenticator verifies the credentials of requests to secured routes and
+// turns on security enforcement. It is called for each scheme the route
+// accepts whose credentials are present, until one returns nil. Returning an
+// *echo.HTTPError or a StatusCoder sets the rejection's status and message.
+func (app *App) SetAuthenticator(authenticator func(c echo.Context, scheme Security) error) {
+	app.authenticator = authenticator
+	app.enforceSecurity = true
+}
+
+// authenticate rejects requests to the secured route that satisfy none of its
+// security schemes. The requirements are resolved per request, so global
+// security and enforcement may be configured after routes are registered.
+func (app *App) authenticate(route *Route, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !app.enforceSecurity {
+			return next(c)
+		}
+		schemes := app.routeSecurity(route)
+		if len(schemes) == 0 {
+			return next(c)
+		}
+
+		var rejection error
+		for _, scheme := range schemes {
+			if !app.hasCredentials(c, scheme) {
+				continue
+			}
+			if app.authenticator == nil {
+				return next(c)
+			}
+			err := app.authenticator(c, scheme)
+			if err == nil {
+				return next(c)
+			}
+			rejection = err
+		}
+
+		if he, ok := rejection.(*echo.HTTPError); ok {
+			return errorResponse(c, he.Code, fmt.Sprintf("%v", he.Message))
+		}
+		var coder StatusCoder
+		if errors.As(rejection, &coder) {
+			return errorResponse(c, coder.StatusCode(), rejection.Error())
+		}
+		if challenge := authChallenge(schemes); challenge != "" {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+		}
+		return errorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	}
+}
+
+// routeSecurity returns the schemes a route accepts: its own, or the global
+// ones unless it is public
+func (app *App) routeSecurity(route *Route) []Security {
+	if route != nil && route.Public {
+		return nil
+	}
+	if route != nil && len(route.Security) > 0 {
+		return route.Security
+	}
+	return app.globalSecurity
+}
+
+// hasCredentials reports whether the request carries non-empty credentials
+// for scheme. apiKey schemes are read from where the route says, else where
+// the registered scheme of that name says, else a header.
+func (app *App) hasCredentials(c echo.Context, scheme Security) bool {
+	req := c.Request()
+	switch scheme.Type {
+	case "bearer", "oauth2":
+		token, ok := cutPrefixFold(req.Header.Get(echo.HeaderAuthorization), "Bearer ")
+		return ok && strings.TrimSpace(token) != ""
+	case "basic":
+		_, _, ok := req.BasicAuth()
+		return ok
+	case "apiKey":
+		if scheme.Name == "" {
+			return false
+		}
+		switch app.apiKeyLocation(scheme) {
+		case "query":
+			return c.QueryParam(scheme.Name) != ""
+		case "cookie":
+			cookie, err := c.Cookie(scheme.Name)
+			return err == nil && cookie.Value != ""
+		default:
+			return req.Header.Get(scheme.Name) != ""
+		}
+	}
+	return false
+}
+
+func (app *App) apiKeyLocation(scheme Security) string {
+	if scheme.In != "" {
+		return scheme.In
+	}
+	if ref := app.spec.Components.SecuritySchemes[scheme.Name]; ref != nil && ref.Value != nil {
+		return ref.Value.In
+	}
+	return "header"
+}
+
+// authChallenge returns the WWW-Authenticate value for the HTTP schemes
+// among schemes
+func authChallenge(schemes []Security) string {
+	var challenges []string
+	seen := map[string]bool{}
+	for _, scheme := range schemes {
+		var challenge string
+		switch scheme.Type {
+		case "bearer", "oauth2":
+			challenge = "Bearer"
+		case "basic":
+			challenge = "Basic"
+		}
+		if challenge != "" && !seen[challenge] {
+			seen[challenge] = true
+			challenges = append(challenges, challenge)
+		}
+	}
+	return strings.Join(challenges, ", ")
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return "", false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,99 @@
+package echonext_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityEnforcement(t *testing.T) {
+	setup := func() *echonext.App {
+		app := echonext.New()
+		app.AddSecurityScheme("api_key", echonext.Security{Type: "apiKey", Name: "api_key", In: "query"})
+		app.GET("/me", func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil }, echonext.Route{
+			Security: []echonext.Security{{Type: "bearer"}, {Type: "apiKey", Name: "api_key"}},
+		})
+		app.GET("/open", func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil })
+		return app
+	}
+	serve := func(app *echonext.App, path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("documentation only by default", func(t *testing.T) {
+		app := setup()
+		assert.Equal(t, http.StatusOK, serve(app, "/me").Code)
+		assert.NotContains(t, app.GenerateOpenAPISpec().Paths["/me"].Get.Responses, "401")
+	})
+
+	t.Run("credential presence", func(t *testing.T) {
+		app := setup()
+		app.SetSecurityEnforcement(true)
+
+		rec := serve(app, "/me")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+		assert.JSONEq(t, `{"success":false,"error":"Unauthorized"}`, rec.Body.String())
+
+		assert.Equal(t, http.StatusUnauthorized, serve(app, "/me", echo.HeaderAuthorization, "Bearer ").Code)
+		assert.Equal(t, http.StatusOK, serve(app, "/me", echo.HeaderAuthorization, "Bearer abc").Code)
+		assert.Equal(t, http.StatusOK, serve(app, "/me?api_key=abc").Code, "the key is read from the registered scheme's location")
+		assert.Equal(t, http.StatusOK, serve(app, "/open").Code)
+
+		responses := app.GenerateOpenAPISpec().Paths["/me"].Get.Responses
+		assert.Contains(t, responses, "401")
+		assert.NotContains(t, app.GenerateOpenAPISpec().Paths["/open"].Get.Responses, "401")
+	})
+
+	t.Run("authenticator", func(t *testing.T) {
+		app := setup()
+		var checked []string
+		app.SetAuthenticator(func(c echo.Context, scheme echonext.Security) error {
+			checked = append(checked, scheme.Type)
+			switch c.Request().Header.Get(echo.HeaderAuthorization) {
+			case "Bearer valid":
+				return nil
+			case "Bearer banned":
+				return echo.NewHTTPError(http.StatusForbidden, "Account suspended")
+			}
+			return errors.New("invalid token")
+		})
+
+		assert.Equal(t, http.StatusOK, serve(app, "/me", echo.HeaderAuthorization, "Bearer valid").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(app, "/me", echo.HeaderAuthorization, "Bearer forged").Code)
+		rec := serve(app, "/me", echo.HeaderAuthorization, "Bearer banned")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Account suspended")
+		assert.Equal(t, []string{"bearer", "bearer", "bearer"}, checked, "schemes without credentials are not checked")
+	})
+
+	t.Run("global security", func(t *testing.T) {
+		app := setup()
+		app.GET("/health", func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil }, echonext.Route{Public: true})
+		app.SetGlobalSecurity([]echonext.Security{{Type: "basic"}})
+		app.SetSecurityEnforcement(true)
+
+		rec := serve(app, "/open")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Basic", rec.Header().Get(echo.HeaderWWWAuthenticate))
+
+		req := httptest.NewRequest(http.MethodGet, "/open", nil)
+		req.SetBasicAuth("ada", "secret")
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, http.StatusOK, serve(app, "/health").Code)
+	})
+}
@@ -0,0 +1,69 @@
+package echonext
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// autoField is a request time field stamped during binding
+type autoField struct {
+	index   []int
+	methods map[string]bool
+}
+
+// autoFields returns the time fields of t tagged `auto:"createOnBind"`, set on
+// POST, or `auto:"updateOnBind"`, set on POST, PUT and PATCH
+func autoFields(t reflect.Type) []autoField {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []autoField
+	for _, field := range reflect.VisibleFields(t) {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType != timeType || !field.IsExported() {
+			continue
+		}
+		switch field.Tag.Get("auto") {
+		case "createOnBind":
+			fields = append(fields, autoField{index: field.Index, methods: map[string]bool{
+				http.MethodPost: true,
+			}})
+		case "updateOnBind":
+			fields = append(fields, autoField{index: field.Index, methods: map[string]bool{
+				http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true,
+			}})
+		}
+	}
+	return fields
+}
+
+// stampAutoFields sets zero auto time fields of req to now
+func stampAutoFields(req reflect.Value, fields []autoField, method string, now time.Time) {
+	for _, f := range fields {
+		if !f.methods[method] {
+			continue
+		}
+		field, err := req.FieldByIndexErr(f.index)
+		if err != nil || !field.IsZero() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			field.Set(reflect.ValueOf(&now))
+		} else {
+			field.Set(reflect.ValueOf(now))
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type SaveNoteRequest struct {
+	ID        string     `param:"id"`
+	Text      string     `json:"text" validate:"required"`
+	CreatedAt time.Time  `json:"created_at" auto:"createOnBind"`
+	UpdatedAt *time.Time `json:"updated_at" auto:"updateOnBind"`
+}
+
+func TestAutoTimestamps(t *testing.T) {
+	app := echonext.New()
+
+	var got SaveNoteRequest
+	save := func(c echo.Context, req SaveNoteRequest) (map[string]string, error) {
+		got = req
+		return map[string]string{"text": req.Text}, nil
+	}
+	app.POST("/notes", save)
+	app.PUT("/notes/:id", save)
+
+	send := func(method, path, body string) {
+		got = SaveNoteRequest{}
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		before := time.Now()
+		send(http.MethodPost, "/notes", `{"text":"hello"}`)
+		assert.False(t, got.CreatedAt.Before(before))
+		if assert.NotNil(t, got.UpdatedAt) {
+			assert.False(t, got.UpdatedAt.Before(before))
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		before := time.Now()
+		send(http.MethodPut, "/notes/1", `{"text":"hello"}`)
+		assert.True(t, got.CreatedAt.IsZero())
+		if assert.NotNil(t, got.UpdatedAt) {
+			assert.False(t, got.UpdatedAt.Before(before))
+		}
+	})
+
+	t.Run("provided value kept", func(t *testing.T) {
+		send(http.MethodPost, "/notes", `{"text":"hello","created_at":"2024-01-02T03:04:05Z"}`)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), got.CreatedAt.UTC())
+	})
+
+	t.Run("documented read-only", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		schema := spec.Paths["/notes"].Post.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.True(t, schema.Properties["created_at"].Value.ReadOnly)
+		assert.True(t, schema.Properties["updated_at"].Value.ReadOnly)
+		assert.False(t, schema.Properties["text"].Value.ReadOnly)
+
+		data, err := json.Marshal(schema)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"readOnly":true`)
+	})
+}
@@ -0,0 +1,60 @@
+package echonext
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetMaxBodyBytes limits the request body of routes that don't set their own
+// Route.MaxBodyBytes. Larger bodies are rejected with 413 Request Entity Too
+// Large. Zero, the default, means no limit.
+func (app *App) SetMaxBodyBytes(limit int64) {
+	app.maxBodyBytes = limit
+}
+
+// bodyLimit returns the body limit for a route, or 0 for none
+func (app *App) bodyLimit(route *Route) int64 {
+	limit := app.maxBodyBytes
+	if route != nil && route.MaxBodyBytes != 0 {
+		limit = route.MaxBodyBytes
+	}
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// limitBody rejects bodies declared larger than the route's limit and caps
+// the rest, so reading past the limit fails instead of filling memory
+func (app *App) limitBody(route *Route, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit := app.bodyLimit(route)
+		req := c.Request()
+		if limit == 0 || req.Body == nil || req.Body == http.NoBody {
+			return next(c)
+		}
+		if req.ContentLength > limit {
+			return bodyTooLarge(c, limit)
+		}
+		req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+		return next(c)
+	}
+}
+
+// exceededLimit reports whether err came from reading past the body limit,
+// and the limit
+func exceededLimit(err error) (int64, bool) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return tooLarge.Limit, true
+	}
+	return 0, false
+}
+
+// bodyTooLarge sends a 413, distinct from the 400 of malformed bodies
+func bodyTooLarge(c echo.Context, limit int64) error {
+	return errorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large: the limit is %d bytes", limit))
+}
@@ -0,0 +1,59 @@
+package echonext_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+	handler := func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{ID: "1", Name: req.Name}, nil
+	}
+
+	app := echonext.New()
+	app.SetMaxBodyBytes(1 << 20)
+	app.POST("/todos", handler, echonext.Route{MaxBodyBytes: 64})
+	app.POST("/imports", handler)
+	app.POST("/archives", handler, echonext.Route{MaxBodyBytes: -1})
+	app.PATCH("/todos/:id", handler, echonext.Route{MaxBodyBytes: 64})
+
+	small := `{"name":"Ada","email":"ada@example.com"}`
+	large := `{"name":"` + strings.Repeat("a", 100) + `","email":"ada@example.com"}`
+
+	send := func(method, path string, body io.Reader) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/todos", strings.NewReader(small)).Code)
+
+	rec := send(http.MethodPost, "/todos", strings.NewReader(large))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Request body too large: the limit is 64 bytes")
+
+	// Bodies without a Content-Length are cut off while reading
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		path := map[string]string{http.MethodPost: "/todos", http.MethodPatch: "/todos/1"}[method]
+		rec := send(method, path, io.MultiReader(strings.NewReader(large)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, method)
+	}
+
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/imports", strings.NewReader(large)).Code, "the app limit applies")
+	huge := `{"name":"Ada","email":"ada@example.com","bio":"` + strings.Repeat("a", 2<<20) + `"}`
+	assert.Equal(t, http.StatusRequestEntityTooLarge, send(http.MethodPost, "/imports", strings.NewReader(huge)).Code)
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/archives", strings.NewReader(huge)).Code, "negative limits disable it")
+
+	spec := app.GenerateOpenAPISpec()
+	assert.Contains(t, spec.Paths["/todos"].Post.Responses, "413")
+	assert.NotContains(t, spec.Paths["/archives"].Post.Responses, "413")
+}
@@ -0,0 +1,78 @@
+// Command echonext-doc generates a map of handler doc comments for
+// App.LoadDocComments, so operation summaries and descriptions can live in
+// Go doc comments next to the handlers. Typical use:
+//
+//	//go:generate go run github.com/abdussamadbello/echonext/cmd/echonext-doc -out handler_docs.go
+//
+// and then at startup:
+//
+//	app.LoadDocComments(handlerDocs)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/abdussamadbello/echonext"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory to parse")
+	out := flag.String("out", "handler_docs.go", "output file")
+	varName := flag.String("var", "handlerDocs", "name of the generated map variable")
+	flag.Parse()
+
+	pkgName, err := packageName(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	comments, err := echonext.ParseDocComments(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(comments))
+	for name := range comments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by echonext-doc. DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "var %s = map[string]string{\n", *varName)
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", name, comments[name])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// packageName returns the name of the non-test package in dir
+func packageName(dir string) (string, error) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+	for name := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no Go package found in %s", dir)
+}
@@ -0,0 +1,181 @@
+package echonext
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// coalescer collapses identical concurrent requests into a single handler
+// execution. It only sees requests reaching this process, so coalescing is
+// per instance rather than across a cluster.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+	vary  func(c echo.Context) []string
+}
+
+var errCoalescedCallFailed = echo.NewHTTPError(http.StatusInternalServerError, "coalesced request failed")
+
+// coalescedCall holds the recorded response of an in-flight request
+type coalescedCall struct {
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+// newCoalescer returns a coalescer keying requests by method, URI and body,
+// and by the values vary returns for them
+func newCoalescer(vary func(c echo.Context) []string) *coalescer {
+	return &coalescer{calls: make(map[string]*coalescedCall), vary: vary}
+}
+
+// coalesceVary returns what, besides method, URI and body, changes who is
+// asking or what they get back on a route: credentials, including the header
+// names of apiKey schemes, cookies, Accept and the raw response opt-out, and
+// the scopes and feature flags resolved for the request. apiKeys in the
+// query are part of the URI.
+func (app *App) coalesceVary(route *Route) func(c echo.Context) []string {
+	return func(c echo.Context) []string {
+		req := c.Request()
+		headers := []string{echo.HeaderAuthorization, "Cookie", echo.HeaderAccept, HeaderRawResponse}
+		for _, scheme := range app.routeSecurity(route) {
+			if scheme.Type == "apiKey" && scheme.Name != "" && app.apiKeyLocation(scheme) == "header" {
+				headers = append(headers, scheme.Name)
+			}
+		}
+		for _, ref := range app.spec.Components.SecuritySchemes {
+			if ref != nil && ref.Value != nil && ref.Value.Type == "apiKey" && ref.Value.In == "header" {
+				headers = append(headers, ref.Value.Name)
+			}
+		}
+
+		values := make([]string, 0, len(headers)+2)
+		for _, name := range headers {
+			values = append(values, name+": "+strings.Join(req.Header.Values(name), ", "))
+		}
+		if app.scopeResolver != nil {
+			scopes := append([]string(nil), app.scopeResolver(c)...)
+			sort.Strings(scopes)
+			values = append(values, "scopes: "+strings.Join(scopes, " "))
+		}
+		if flags, ok := c.Get(featuresKey).(map[string]bool); ok {
+			enabled := make([]string, 0, len(flags))
+			for name, on := range flags {
+				if on {
+					enabled = append(enabled, name)
+				}
+			}
+			sort.Strings(enabled)
+			values = append(values, "features: "+strings.Join(enabled, " "))
+		}
+		return values
+	}
+}
+
+// middleware runs the first request for a key and replays its response to
+// identical requests that arrive while it is in flight
+func (co *coalescer) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key, err := coalesceKey(c.Request(), co.vary(c))
+		if limit, tooLarge := exceededLimit(err); tooLarge {
+			return bodyTooLarge(c, limit)
+		}
+		if err != nil {
+			return errorResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		}
+
+		co.mu.Lock()
+		if call, ok := co.calls[key]; ok {
+			co.mu.Unlock()
+			select {
+			case <-call.done:
+				return call.replay(c)
+			case <-c.Request().Context().Done():
+				return handlerErrorResponse(c, c.Request().Context().Err())
+			}
+		}
+		call := &coalescedCall{done: make(chan struct{})}
+		co.calls[key] = call
+		co.mu.Unlock()
+
+		// Release waiters even if the handler panics
+		call.err = errCoalescedCallFailed
+		defer func() {
+			co.mu.Lock()
+			delete(co.calls, key)
+			co.mu.Unlock()
+			close(call.done)
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+		err = next(c)
+		c.Response().Writer = recorder.ResponseWriter
+
+		call.status = c.Response().Status
+		call.header = c.Response().Header().Clone()
+		call.body = recorder.body.Bytes()
+		call.err = err
+		return err
+	}
+}
+
+// replay writes the recorded response to another request. Correlation
+// headers belong to the first request, so the caller keeps its own.
+func (call *coalescedCall) replay(c echo.Context) error {
+	if call.err != nil {
+		return call.err
+	}
+	header := c.Response().Header()
+	for name, values := range call.header {
+		if name == http.CanonicalHeaderKey(echo.HeaderXRequestID) || name == http.CanonicalHeaderKey(HeaderTraceparent) {
+			continue
+		}
+		header[name] = values
+	}
+	c.Response().WriteHeader(call.status)
+	_, err := c.Response().Write(call.body)
+	return err
+}
+
+// coalesceKey identifies a request by method, URI, the values it varies by
+// and body hash. The body is restored so the handler can still read it.
+func coalesceKey(req *http.Request, vary []string) (string, error) {
+	hash := sha256.New()
+	io.WriteString(hash, req.Method+" "+req.URL.RequestURI()+"\n")
+	for _, value := range vary {
+		io.WriteString(hash, value+"\n")
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		hash.Write(body)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// responseRecorder tees a response body while it is written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
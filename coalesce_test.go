@@ -0,0 +1,153 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesce(t *testing.T) {
+	app := echonext.New()
+
+	var executions atomic.Int32
+	app.POST("/reports", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		executions.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return TestUser{ID: "report", Name: req.Name}, nil
+	}, echonext.Route{
+		Coalesce: true,
+	})
+
+	const callers = 10
+	codes := make([]int, callers)
+	bodies := make([]string, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader(`{"name":"Weekly","email":"ops@example.com"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+			bodies[i] = rec.Body.String()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), executions.Load())
+	for i := 0; i < callers; i++ {
+		assert.Equal(t, http.StatusOK, codes[i])
+		var response echonext.Response[TestUser]
+		assert.NoError(t, json.Unmarshal([]byte(bodies[i]), &response))
+		assert.Equal(t, "Weekly", response.Data.Name)
+	}
+
+	// Requests after completion run the handler again
+	req := httptest.NewRequest(http.MethodPost, "/reports", strings.NewReader(`{"name":"Weekly","email":"ops@example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, int32(2), executions.Load())
+}
+
+func TestCoalesceKeysByCookie(t *testing.T) {
+	app := echonext.New()
+
+	var executions atomic.Int32
+	app.GET("/me", func(c echo.Context) (TestUser, error) {
+		executions.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		session, err := c.Cookie("session")
+		if err != nil {
+			return TestUser{}, err
+		}
+		return TestUser{ID: session.Value}, nil
+	}, echonext.Route{
+		Coalesce: true,
+	})
+
+	sessions := []string{"alice", "bob"}
+	ids := make([]string, len(sessions))
+
+	var wg sync.WaitGroup
+	for i, session := range sessions {
+		wg.Add(1)
+		go func(i int, session string) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			req.AddCookie(&http.Cookie{Name: "session", Value: session})
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			var response echonext.Response[TestUser]
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			ids[i] = response.Data.ID
+		}(i, session)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), executions.Load())
+	assert.Equal(t, sessions, ids)
+}
+
+func TestCoalesceKeysByResponseShape(t *testing.T) {
+	app := echonext.New()
+	app.SetScopeResolver(func(c echo.Context) []string {
+		return strings.Split(c.Request().Header.Get("X-Scopes"), ",")
+	})
+
+	var executions atomic.Int32
+	app.GET("/accounts/:id", func(c echo.Context) (Account, error) {
+		executions.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return Account{ID: c.Param("id"), Internal: "VIP customer"}, nil
+	}, echonext.Route{
+		Coalesce: true,
+	})
+
+	serve := func(requests ...map[string]string) []string {
+		executions.Store(0)
+		bodies := make([]string, len(requests))
+		var wg sync.WaitGroup
+		for i, headers := range requests {
+			wg.Add(1)
+			go func(i int, headers map[string]string) {
+				defer wg.Done()
+				req := httptest.NewRequest(http.MethodGet, "/accounts/1", nil)
+				for name, value := range headers {
+					req.Header.Set(name, value)
+				}
+				rec := httptest.NewRecorder()
+				app.ServeHTTP(rec, req)
+				bodies[i] = rec.Body.String()
+			}(i, headers)
+		}
+		wg.Wait()
+		return bodies
+	}
+
+	t.Run("raw and enveloped", func(t *testing.T) {
+		bodies := serve(map[string]string{}, map[string]string{echonext.HeaderRawResponse: "true"})
+		assert.Equal(t, int32(2), executions.Load())
+		assert.Contains(t, bodies[0], `"success":true`)
+		assert.NotContains(t, bodies[1], `"success"`)
+		assert.Contains(t, bodies[1], `"id":"1"`)
+	})
+
+	t.Run("different scopes", func(t *testing.T) {
+		bodies := serve(map[string]string{"X-Scopes": "admin"}, map[string]string{"X-Scopes": "user"})
+		assert.Equal(t, int32(2), executions.Load())
+		assert.Contains(t, bodies[0], "VIP customer")
+		assert.NotContains(t, bodies[1], "VIP customer")
+	})
+}
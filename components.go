@@ -0,0 +1,195 @@
+package echonext
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// componentSchemaPrefix is the JSON pointer prefix for component schemas
+const componentSchemaPrefix = "#/components/schemas/"
+
+// SetComponentPrefix prefixes every generated component schema name, e.g.
+// "Billing_" turns Invoice into Billing_Invoice, so specs from several apps can
+// be merged without collisions. Set it before generating the spec.
+func (app *App) SetComponentPrefix(prefix string) {
+	app.componentPrefix = prefix
+	app.invalidateSchemas()
+}
+
+// SetInlineSchemas documents named structs inline wherever they appear
+// instead of as shared components referenced by $ref. Generic and recursive
+// types are still components, since they can't be inlined readably.
+func (app *App) SetInlineSchemas(inline bool) {
+	app.inlineSchemas = inline
+	app.invalidateSchemas()
+}
+
+// schemaRef returns a schema reference for t. Types that are documented as
+// reusable components are registered in the spec once and referenced by $ref;
+// everything else is generated inline.
+func (app *App) schemaRef(t reflect.Type) *openapi3.SchemaRef {
+	base := t
+	for base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+
+	name, ok := app.componentName(base)
+	if !ok {
+		// Track inlined structs so a type that contains itself becomes a component
+		if base.Kind() == reflect.Struct {
+			if app.inlining == nil {
+				app.inlining = make(map[reflect.Type]bool)
+			}
+			app.inlining[base] = true
+			defer delete(app.inlining, base)
+		}
+		return &openapi3.SchemaRef{Value: app.generateSchema(t)}
+	}
+
+	component, exists := app.spec.Components.Schemas[name]
+	if !exists {
+		// Register before generating so self-referential types resolve to the $ref
+		component = &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+		app.spec.Components.Schemas[name] = component
+		*component.Value = *app.generateSchema(base)
+	}
+	return &openapi3.SchemaRef{Ref: componentSchemaPrefix + name, Value: component.Value}
+}
+
+// errorSchemaRef returns a reference to the shared ErrorResponse component,
+// registering it on first use
+func (app *App) errorSchemaRef() *openapi3.SchemaRef {
+	name := app.componentPrefix + "ErrorResponse"
+	component, exists := app.spec.Components.Schemas[name]
+	if !exists {
+		detail := &openapi3.SchemaRef{Value: app.generateSchema(reflect.TypeOf(FieldError{}))}
+		component = app.envelope.ErrorSchema(detail)
+		app.spec.Components.Schemas[name] = component
+	}
+	return &openapi3.SchemaRef{Ref: componentSchemaPrefix + name, Value: component.Value}
+}
+
+// componentName returns the component schema name for t and whether t is
+// documented as a component. Named structs are components unless schemas are
+// inlined; instantiated generic structs always are, since their inline names
+// are unreadable.
+func (app *App) componentName(t reflect.Type) (string, bool) {
+	if t.Kind() != reflect.Struct || t.Name() == "" || t == timeType || t == fileHeaderType {
+		return "", false
+	}
+	if name, ok := app.componentNames[t]; ok {
+		return name, true
+	}
+	if app.inlineSchemas && !strings.Contains(t.Name(), "[") && !app.inlining[t] {
+		return "", false
+	}
+
+	// Types from different packages may share a name; qualify later ones by package
+	name := app.componentPrefix + cleanTypeName(t.Name())
+	if taken := app.takenComponentNames(); taken[name] {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = uniqueID(app.componentPrefix+exportedName(cleanIdentifier(pkg))+cleanTypeName(t.Name()), taken)
+	}
+
+	if app.componentNames == nil {
+		app.componentNames = make(map[reflect.Type]string)
+	}
+	app.componentNames[t] = name
+	return name, true
+}
+
+// takenComponentNames returns the set of component names in use
+func (app *App) takenComponentNames() map[string]bool {
+	taken := map[string]bool{app.componentPrefix + "ErrorResponse": true}
+	for _, name := range app.componentNames {
+		taken[name] = true
+	}
+	return taken
+}
+
+// cleanIdentifier drops characters that aren't letters or digits
+func cleanIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// cleanTypeName turns a reflected type name such as
+// "Page[github.com/acme/api.Todo]" into an identifier like "PageTodo"
+func cleanTypeName(name string) string {
+	open := strings.Index(name, "[")
+	if open < 0 || !strings.HasSuffix(name, "]") {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name[:open])
+	for _, arg := range splitTypeArgs(name[open+1 : len(name)-1]) {
+		b.WriteString(cleanTypeArg(arg))
+	}
+	return b.String()
+}
+
+// cleanTypeArg names a single type argument, dropping package paths
+func cleanTypeArg(arg string) string {
+	arg = strings.TrimLeft(arg, "*")
+	switch {
+	case strings.HasPrefix(arg, "[]"):
+		return cleanTypeArg(arg[2:]) + "List"
+	case strings.HasPrefix(arg, "map["):
+		depth := 0
+		for i := 3; i < len(arg); i++ {
+			switch arg[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return "Map" + cleanTypeArg(arg[4:i]) + cleanTypeArg(arg[i+1:])
+				}
+			}
+		}
+	}
+
+	// Strip the package qualifier, which may itself contain dots and slashes
+	qualified := arg
+	if open := strings.Index(arg, "["); open >= 0 {
+		qualified = arg[:open]
+	}
+	if dot := strings.LastIndex(qualified, "."); dot >= 0 {
+		arg = arg[dot+1:]
+	}
+
+	arg = cleanTypeName(arg)
+	if arg == "" {
+		return arg
+	}
+	return strings.ToUpper(arg[:1]) + arg[1:]
+}
+
+// splitTypeArgs splits a comma-separated type argument list, respecting nested brackets
+func splitTypeArgs(args string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range args {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, args[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, args[start:])
+}
@@ -0,0 +1,258 @@
+package echonext_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+func TestGenericComponentSchemas(t *testing.T) {
+	app := echonext.New()
+
+	app.GET("/users", func(c echo.Context) (Page[TestUser], error) {
+		return Page[TestUser]{}, nil
+	})
+	app.GET("/tasks", func(c echo.Context) (Page[Task], error) {
+		return Page[Task]{}, nil
+	})
+	app.GET("/nested", func(c echo.Context) (Page[map[string][]TestUser], error) {
+		return Page[map[string][]TestUser]{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	schemas := spec.Components.Schemas
+
+	assert.Contains(t, schemas, "PageTestUser")
+	assert.Contains(t, schemas, "PageTask")
+	assert.Contains(t, schemas, "PageMapStringTestUserList")
+	assert.Contains(t, schemas["PageTestUser"].Value.Properties, "items")
+
+	users := spec.Paths["/users"].Get.Responses["200"].Value.Content["application/json"].Schema.Value
+	assert.Equal(t, "#/components/schemas/PageTestUser", users.Properties["data"].Ref)
+
+	tasks := spec.Paths["/tasks"].Get.Responses["200"].Value.Content["application/json"].Schema.Value
+	assert.Equal(t, "#/components/schemas/PageTask", tasks.Properties["data"].Ref)
+}
+
+func TestErrorResponseComponent(t *testing.T) {
+	app := echonext.New()
+
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return nil, nil
+	})
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+
+	assert.Contains(t, spec.Components.Schemas, "ErrorResponse")
+	errorSchema := spec.Components.Schemas["ErrorResponse"].Value
+	assert.Contains(t, errorSchema.Properties, "error")
+	assert.Contains(t, errorSchema.Properties, "details")
+
+	for _, op := range []*openapi3.Operation{spec.Paths["/users"].Get, spec.Paths["/users"].Post} {
+		for _, status := range []string{"400", "500"} {
+			schema := op.Responses[status].Value.Content["application/json"].Schema
+			assert.Equal(t, "#/components/schemas/ErrorResponse", schema.Ref)
+		}
+	}
+}
+
+func TestComponentPrefix(t *testing.T) {
+	app := echonext.New()
+	app.SetComponentPrefix("Billing_")
+
+	app.GET("/invoices", func(c echo.Context) (Page[Task], error) {
+		return Page[Task]{}, nil
+	})
+	app.POST("/invoices", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	schemas := spec.Components.Schemas
+
+	assert.Contains(t, schemas, "Billing_PageTask")
+	assert.Contains(t, schemas, "Billing_ErrorResponse")
+	assert.NotContains(t, schemas, "PageTask")
+	assert.NotContains(t, schemas, "ErrorResponse")
+
+	list := spec.Paths["/invoices"].Get.Responses["200"].Value.Content["application/json"].Schema.Value
+	assert.Equal(t, "#/components/schemas/Billing_PageTask", list.Properties["data"].Ref)
+
+	for _, response := range spec.Paths["/invoices"].Post.Responses {
+		if schema := response.Value.Content["application/json"].Schema; schema.Ref != "" {
+			assert.Equal(t, "#/components/schemas/Billing_ErrorResponse", schema.Ref)
+		}
+	}
+	assert.NoError(t, spec.Validate(context.Background()))
+}
+
+type Category struct {
+	Name     string     `json:"name"`
+	Parent   *Category  `json:"parent,omitempty"`
+	Children []Category `json:"children"`
+}
+
+type URL struct {
+	Href string `json:"href"`
+}
+
+type Link struct {
+	Local  URL     `json:"local"`
+	Parsed url.URL `json:"parsed"`
+	Owner  Author  `json:"owner" scope:"admin"`
+}
+
+func TestNamedStructComponents(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return nil, nil
+	})
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+	app.GET("/categories", func(c echo.Context) (Category, error) {
+		return Category{}, nil
+	})
+	app.GET("/links", func(c echo.Context) (Link, error) {
+		return Link{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	schemas := spec.Components.Schemas
+	data := func(path string, op func(*openapi3.PathItem) *openapi3.Operation) *openapi3.SchemaRef {
+		return op(spec.Paths[path]).Responses["200"].Value.Content["application/json"].Schema.Value.Properties["data"]
+	}
+	get := func(item *openapi3.PathItem) *openapi3.Operation { return item.Get }
+	post := func(item *openapi3.PathItem) *openapi3.Operation { return item.Post }
+
+	t.Run("shared", func(t *testing.T) {
+		assert.Contains(t, schemas, "TestUser")
+		assert.Equal(t, "#/components/schemas/TestUser", data("/users", get).Value.Items.Ref)
+		assert.Equal(t, "#/components/schemas/TestUser", data("/users", post).Ref)
+
+		body := spec.Paths["/users"].Post.RequestBody.Value.Content["application/json"].Schema
+		assert.Equal(t, "#/components/schemas/CreateUserRequest", body.Ref)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		category := schemas["Category"].Value
+		parent := category.Properties["parent"].Value
+		assert.True(t, parent.Nullable)
+		assert.Equal(t, "#/components/schemas/Category", parent.AllOf[0].Ref)
+		assert.Equal(t, "#/components/schemas/Category", category.Properties["children"].Value.Items.Ref)
+	})
+
+	t.Run("name collisions", func(t *testing.T) {
+		link := schemas["Link"].Value
+		assert.Equal(t, "#/components/schemas/URL", link.Properties["local"].Ref)
+		assert.Equal(t, "#/components/schemas/UrlURL", link.Properties["parsed"].Ref)
+		assert.Contains(t, schemas["URL"].Value.Properties, "href")
+		assert.Contains(t, schemas["UrlURL"].Value.Properties, "Host")
+	})
+
+	t.Run("decorated refs", func(t *testing.T) {
+		owner := schemas["Link"].Value.Properties["owner"]
+		assert.Empty(t, owner.Ref)
+		assert.Equal(t, "admin", owner.Value.Extensions["x-required-scope"])
+		if assert.Len(t, owner.Value.AllOf, 1) {
+			assert.Equal(t, "#/components/schemas/Author", owner.Value.AllOf[0].Ref)
+		}
+		assert.NotContains(t, schemas["Author"].Value.Extensions, "x-required-scope")
+	})
+
+	assert.NoError(t, spec.Validate(context.Background()))
+}
+
+func TestInlineSchemas(t *testing.T) {
+	app := echonext.New()
+	app.SetInlineSchemas(true)
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return nil, nil
+	})
+	app.GET("/categories", func(c echo.Context) (Category, error) {
+		return Category{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	assert.NotContains(t, spec.Components.Schemas, "TestUser")
+
+	users := spec.Paths["/users"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Properties["data"]
+	assert.Empty(t, users.Value.Items.Ref)
+	assert.Contains(t, users.Value.Items.Value.Properties, "name")
+
+	// Recursive types can't be inlined, so they remain components
+	assert.Contains(t, spec.Components.Schemas, "Category")
+	category := spec.Components.Schemas["Category"].Value
+	assert.Equal(t, "#/components/schemas/Category", category.Properties["parent"].Value.AllOf[0].Ref)
+}
+
+func TestListResponseSchemas(t *testing.T) {
+	type Item struct {
+		ID    string `json:"id" validate:"required"`
+		Title string `json:"title" validate:"required,max=100"`
+		Note  string `json:"note,omitempty"`
+	}
+	type Checksum struct {
+		Digest   [2]Item         `json:"digest"`
+		Raw      []byte          `json:"raw"`
+		Metadata json.RawMessage `json:"metadata"`
+	}
+
+	app := echonext.New()
+	app.GET("/items", func(c echo.Context) ([]Item, error) { return nil, nil })
+	app.GET("/pointers", func(c echo.Context) ([]*Item, error) { return nil, nil })
+	app.GET("/checksum", func(c echo.Context) (Checksum, error) { return Checksum{}, nil })
+
+	spec := app.GenerateOpenAPISpec()
+	list := func(path string) *openapi3.Schema {
+		return spec.Paths[path].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Properties["data"].Value
+	}
+
+	for _, path := range []string{"/items", "/pointers"} {
+		data := list(path)
+		assert.Equal(t, "array", data.Type)
+		assert.Equal(t, "#/components/schemas/Item", data.Items.Ref, path)
+	}
+	item := spec.Components.Schemas["Item"].Value
+	assert.Equal(t, []string{"id", "title"}, item.Required)
+	assert.Len(t, item.Properties, 3)
+	assert.Equal(t, uint64(100), *item.Properties["title"].Value.MaxLength)
+
+	checksum := spec.Components.Schemas["Checksum"].Value
+	digest := checksum.Properties["digest"].Value
+	assert.Equal(t, "array", digest.Type)
+	assert.Equal(t, uint64(2), digest.MinItems)
+	assert.Equal(t, uint64(2), *digest.MaxItems)
+	assert.Equal(t, "#/components/schemas/Item", digest.Items.Ref)
+	assert.Equal(t, "byte", checksum.Properties["raw"].Value.Format)
+	// Raw JSON is sent inline, so it may be any value
+	metadata := checksum.Properties["metadata"].Value
+	assert.Empty(t, metadata.Type)
+	assert.Empty(t, metadata.Format)
+
+	t.Run("inline", func(t *testing.T) {
+		app := echonext.New()
+		app.SetInlineSchemas(true)
+		app.GET("/items", func(c echo.Context) ([]Item, error) { return nil, nil })
+
+		spec := app.GenerateOpenAPISpec()
+		items := spec.Paths["/items"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Properties["data"].Value.Items
+		assert.Empty(t, items.Ref)
+		assert.Equal(t, []string{"id", "title"}, items.Value.Required)
+		assert.Len(t, items.Value.Properties, 3)
+	})
+}
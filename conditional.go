@@ -0,0 +1,54 @@
+package echonext
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LastModifier is implemented by response values that know when they last changed
+type LastModifier interface {
+	LastModified() time.Time
+}
+
+const lastModifiedKey = "echonext.last_modified"
+
+// SetLastModified records the modification time of the resource a handler returns.
+// It takes precedence over a LastModifier implemented by the response value.
+func SetLastModified(c echo.Context, t time.Time) {
+	c.Set(lastModifiedKey, t)
+}
+
+// notModified sets the Last-Modified header for the response and reports whether
+// the request's If-Modified-Since precondition allows answering with 304.
+// If-None-Match takes precedence, so If-Modified-Since is ignored when present.
+func notModified(c echo.Context, data interface{}) bool {
+	var modified time.Time
+	if t, ok := c.Get(lastModifiedKey).(time.Time); ok {
+		modified = t
+	} else if lm, ok := data.(LastModifier); ok {
+		modified = lm.LastModified()
+	}
+	if modified.IsZero() {
+		return false
+	}
+
+	// HTTP dates only carry second precision
+	modified = modified.UTC().Truncate(time.Second)
+	c.Response().Header().Set(echo.HeaderLastModified, modified.Format(http.TimeFormat))
+
+	req := c.Request()
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if req.Header.Get("If-None-Match") != "" {
+		return false
+	}
+
+	since, err := http.ParseTime(req.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
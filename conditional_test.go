@@ -0,0 +1,81 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type VersionedDoc struct {
+	ID        string    `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (d VersionedDoc) LastModified() time.Time {
+	return d.UpdatedAt
+}
+
+func TestLastModified(t *testing.T) {
+	app := echonext.New()
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	app.GET("/docs/:id", func(c echo.Context) (VersionedDoc, error) {
+		return VersionedDoc{ID: c.Param("id"), UpdatedAt: updated}, nil
+	})
+
+	app.GET("/reports/:id", func(c echo.Context) (TestUser, error) {
+		echonext.SetLastModified(c, updated)
+		return TestUser{ID: c.Param("id")}, nil
+	})
+
+	doRequest := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("sets Last-Modified", func(t *testing.T) {
+		rec := doRequest("/docs/1", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, updated.Format(http.TimeFormat), rec.Header().Get(echo.HeaderLastModified))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		rec := doRequest("/docs/1", map[string]string{
+			echo.HeaderIfModifiedSince: updated.Format(http.TimeFormat),
+		})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("modified since", func(t *testing.T) {
+		rec := doRequest("/docs/1", map[string]string{
+			echo.HeaderIfModifiedSince: updated.Add(-time.Hour).Format(http.TimeFormat),
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("set via context", func(t *testing.T) {
+		rec := doRequest("/reports/1", map[string]string{
+			echo.HeaderIfModifiedSince: updated.Format(http.TimeFormat),
+		})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("If-None-Match takes precedence", func(t *testing.T) {
+		rec := doRequest("/docs/1", map[string]string{
+			echo.HeaderIfModifiedSince: updated.Format(http.TimeFormat),
+			"If-None-Match":            `"other"`,
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,61 @@
+package echonext
+
+import (
+	"fmt"
+	"mime"
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// rawRequestBody dispatches on Route.ContentSchemas, reporting whether the
+// request body is declared as raw and left for the handler to read, and
+// whether the content type is accepted at all
+func rawRequestBody(c echo.Context, route *Route) (raw bool, accepted bool) {
+	if route == nil || len(route.ContentSchemas) == 0 {
+		return false, true
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	schema, ok := route.ContentSchemas[mediaType]
+	if !ok {
+		return false, false
+	}
+	return isRawBody(reflect.TypeOf(schema)), true
+}
+
+// checkContentSchemas panics when a parsed body type in schemas isn't the
+// handler's request type, since every parsed body is bound into it and the
+// spec would document a body the handler never receives
+func checkContentSchemas(schemas map[string]interface{}, requestType reflect.Type) {
+	for contentType, schema := range schemas {
+		t := reflect.TypeOf(schema)
+		if isRawBody(t) {
+			continue
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		want := requestType
+		for want != nil && want.Kind() == reflect.Ptr {
+			want = want.Elem()
+		}
+		if t != want {
+			panic(fmt.Sprintf("echonext: content schema %s for %q must be the request type %v or []byte", t, contentType, want))
+		}
+	}
+}
+
+// isRawBody reports whether a body type is passed through unparsed
+func isRawBody(t reflect.Type) bool {
+	return t == nil || t.Kind() == reflect.String ||
+		(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// contentSchemaRef documents a body type declared in Route.ContentSchemas
+func (app *App) contentSchemaRef(t reflect.Type) *openapi3.SchemaRef {
+	if isRawBody(t) {
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string", Format: "binary"}}
+	}
+	return app.schemaRef(t)
+}
@@ -0,0 +1,82 @@
+package echonext_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type ImportUsersRequest struct {
+	Users []CreateUserRequest `json:"users" validate:"required,dive"`
+}
+
+func TestContentSchemas(t *testing.T) {
+	app := echonext.New()
+	app.POST("/users/import", func(c echo.Context, req ImportUsersRequest) (int, error) {
+		if c.Request().Header.Get(echo.HeaderContentType) == "text/csv" {
+			records, err := csv.NewReader(c.Request().Body).ReadAll()
+			return len(records), err
+		}
+		return len(req.Users), nil
+	}, echonext.Route{
+		ContentSchemas: map[string]interface{}{
+			"application/json": ImportUsersRequest{},
+			"text/csv":         []byte(nil),
+		},
+	})
+
+	serve := func(contentType, body string) (int, echonext.Response[int]) {
+		req := httptest.NewRequest(http.MethodPost, "/users/import", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		var response echonext.Response[int]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return rec.Code, response
+	}
+
+	t.Run("json", func(t *testing.T) {
+		status, response := serve(echo.MIMEApplicationJSON, `{"users":[{"name":"John","email":"john@example.com"}]}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, response.Data)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		status, response := serve("text/csv", "John,john@example.com\nJane,jane@example.com\n")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 2, response.Data)
+	})
+
+	t.Run("undeclared", func(t *testing.T) {
+		status, _ := serve(echo.MIMETextPlain, "John")
+		assert.Equal(t, http.StatusUnsupportedMediaType, status)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		content := app.GenerateOpenAPISpec().Paths["/users/import"].Post.RequestBody.Value.Content
+		assert.Len(t, content, 2)
+		assert.Contains(t, content["application/json"].Schema.Value.Properties, "users")
+		assert.Equal(t, "string", content["text/csv"].Schema.Value.Type)
+		assert.Equal(t, "binary", content["text/csv"].Schema.Value.Format)
+	})
+
+	t.Run("other parsed types rejected", func(t *testing.T) {
+		assert.PanicsWithValue(t, `echonext: content schema echonext_test.CreateUserRequest for "application/xml" must be the request type echonext_test.ImportUsersRequest or []byte`, func() {
+			app.POST("/users/import-xml", func(c echo.Context, req ImportUsersRequest) (int, error) {
+				return len(req.Users), nil
+			}, echonext.Route{
+				ContentSchemas: map[string]interface{}{
+					"application/json": &ImportUsersRequest{},
+					"application/xml":  CreateUserRequest{},
+				},
+			})
+		})
+	})
+}
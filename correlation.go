@@ -0,0 +1,87 @@
+package echonext
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderTraceparent is the W3C Trace Context header
+const HeaderTraceparent = "traceparent"
+
+const correlationKey = "echonext.correlation"
+
+// CorrelationIDs identifies a request across services and logs
+type CorrelationIDs struct {
+	RequestID string
+	TraceID   string // 32 hex characters
+	SpanID    string // 16 hex characters
+}
+
+// Traceparent formats the IDs as a W3C traceparent header value
+func (ids CorrelationIDs) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", ids.TraceID, ids.SpanID)
+}
+
+// UseCorrelation extracts or generates request, trace and span IDs for every
+// request. The IDs are echoed in the X-Request-ID and traceparent response
+// headers, exposed via Correlation(c), and included as correlation_id in error
+// responses. Echo's Logger middleware picks up the request ID through ${id}.
+func (app *App) UseCorrelation() {
+	app.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			ids := CorrelationIDs{
+				RequestID: req.Header.Get(echo.HeaderXRequestID),
+				SpanID:    randomHex(8),
+			}
+			if ids.RequestID == "" {
+				ids.RequestID = randomHex(16)
+			}
+			// Continue an incoming trace, starting a new span for this server
+			if traceID, ok := parseTraceparent(req.Header.Get(HeaderTraceparent)); ok {
+				ids.TraceID = traceID
+			} else {
+				ids.TraceID = randomHex(16)
+			}
+
+			c.Set(correlationKey, ids)
+			header := c.Response().Header()
+			header.Set(echo.HeaderXRequestID, ids.RequestID)
+			header.Set(HeaderTraceparent, ids.Traceparent())
+
+			return next(c)
+		}
+	})
+}
+
+// Correlation returns the correlation IDs of the current request.
+// The zero value is returned when UseCorrelation is not installed.
+func Correlation(c echo.Context) CorrelationIDs {
+	ids, _ := c.Get(correlationKey).(CorrelationIDs)
+	return ids
+}
+
+// parseTraceparent returns the trace ID of a valid W3C traceparent value
+func parseTraceparent(value string) (string, bool) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || parts[1] == strings.Repeat("0", 32) {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
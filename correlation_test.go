@@ -0,0 +1,56 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelation(t *testing.T) {
+	app := echonext.New()
+	app.UseCorrelation()
+
+	var seen echonext.CorrelationIDs
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		seen = echonext.Correlation(c)
+		return TestUser{}, echo.NewHTTPError(http.StatusNotFound, "user not found")
+	})
+
+	t.Run("propagates incoming IDs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-123")
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, "req-123", seen.RequestID)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", seen.TraceID)
+		assert.Len(t, seen.SpanID, 16)
+		assert.NotEqual(t, "00f067aa0ba902b7", seen.SpanID)
+
+		assert.Equal(t, "req-123", rec.Header().Get(echo.HeaderXRequestID))
+		assert.Equal(t, seen.Traceparent(), rec.Header().Get("traceparent"))
+
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "req-123", response.CorrelationID)
+	})
+
+	t.Run("generates missing IDs", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Len(t, seen.RequestID, 32)
+		assert.Len(t, seen.TraceID, 32)
+		assert.Equal(t, seen.RequestID, rec.Header().Get(echo.HeaderXRequestID))
+		assert.Contains(t, rec.Header().Get("traceparent"), seen.TraceID)
+	})
+}
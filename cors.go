@@ -0,0 +1,101 @@
+package echonext
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// UseCORS installs Echo's CORS middleware for the whole app and keeps the
+// configuration so it can be documented with DocumentCORS
+func (app *App) UseCORS(config middleware.CORSConfig) {
+	if len(config.AllowOrigins) == 0 && config.AllowOriginFunc == nil {
+		config.AllowOrigins = middleware.DefaultCORSConfig.AllowOrigins
+	}
+	if len(config.AllowMethods) == 0 {
+		config.AllowMethods = middleware.DefaultCORSConfig.AllowMethods
+	}
+	app.cors = &config
+	app.Use(middleware.CORSWithConfig(config))
+}
+
+// DocumentCORS adds an OPTIONS operation to every path in the spec describing
+// the preflight response of the CORS middleware installed with UseCORS
+func (app *App) DocumentCORS() {
+	if app.cors == nil {
+		panic("echonext: DocumentCORS requires CORS to be installed with UseCORS")
+	}
+	app.documentCORS = true
+}
+
+// addCORSOperations documents the preflight response on each path
+func (app *App) addCORSOperations() {
+	for _, item := range app.spec.Paths {
+		if item.Options != nil {
+			continue
+		}
+		item.Options = &openapi3.Operation{
+			Summary: "CORS preflight",
+			Responses: openapi3.Responses{
+				strconv.Itoa(http.StatusNoContent): &openapi3.ResponseRef{
+					Value: &openapi3.Response{
+						Description: strPtr("CORS preflight response"),
+						Headers:     corsHeaders(*app.cors),
+					},
+				},
+			},
+		}
+		// Browsers send preflights without credentials
+		if len(app.spec.Security) > 0 {
+			item.Options.Security = &openapi3.SecurityRequirements{}
+		}
+	}
+}
+
+// corsHeaders returns the OpenAPI headers sent in a preflight response
+func corsHeaders(config middleware.CORSConfig) openapi3.Headers {
+	header := func(description, typ string, example interface{}) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{
+			Value: &openapi3.Header{
+				Parameter: openapi3.Parameter{
+					Description: description,
+					Schema: &openapi3.SchemaRef{
+						Value: &openapi3.Schema{Type: typ, Example: example},
+					},
+				},
+			},
+		}
+	}
+
+	origins := "Origins allowed to make cross-origin requests: " + strings.Join(config.AllowOrigins, ", ")
+	if config.AllowOriginFunc != nil {
+		origins = "Origin of the request when allowed by the server"
+	}
+	headers := openapi3.Headers{
+		echo.HeaderAccessControlAllowOrigin:  header(origins, "string", firstOrEmpty(config.AllowOrigins)),
+		echo.HeaderAccessControlAllowMethods: header("Methods allowed for cross-origin requests", "string", strings.Join(config.AllowMethods, ",")),
+	}
+	if len(config.AllowHeaders) > 0 {
+		headers[echo.HeaderAccessControlAllowHeaders] = header("Request headers allowed for cross-origin requests", "string", strings.Join(config.AllowHeaders, ","))
+	} else {
+		headers[echo.HeaderAccessControlAllowHeaders] = header("Request headers allowed for cross-origin requests, echoed from Access-Control-Request-Headers", "string", nil)
+	}
+	if config.AllowCredentials {
+		headers[echo.HeaderAccessControlAllowCredentials] = header("Whether credentials may be sent with cross-origin requests", "string", "true")
+	}
+	if config.MaxAge > 0 {
+		headers[echo.HeaderAccessControlMaxAge] = header("Seconds the preflight response may be cached", "integer", config.MaxAge)
+	}
+	return headers
+}
+
+func firstOrEmpty(values []string) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
@@ -0,0 +1,52 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentCORS(t *testing.T) {
+	app := echonext.New()
+	app.UseCORS(middleware.CORSConfig{
+		AllowOrigins: []string{"https://example.com"},
+		AllowHeaders: []string{echo.HeaderAuthorization, echo.HeaderContentType},
+		MaxAge:       600,
+	})
+	app.DocumentCORS()
+
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		return TestUser{ID: c.Param("id")}, nil
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		options := spec.Paths["/users/{id}"].Options
+		if assert.NotNil(t, options) {
+			headers := options.Responses["204"].Value.Headers
+			assert.Equal(t, "https://example.com", headers[echo.HeaderAccessControlAllowOrigin].Value.Schema.Value.Example)
+			assert.Equal(t, "Authorization,Content-Type", headers[echo.HeaderAccessControlAllowHeaders].Value.Schema.Value.Example)
+			assert.Contains(t, headers[echo.HeaderAccessControlAllowMethods].Value.Schema.Value.Example, http.MethodGet)
+			assert.Equal(t, 600, headers[echo.HeaderAccessControlMaxAge].Value.Schema.Value.Example)
+		}
+	})
+
+	t.Run("preflight served", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/users/1", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://example.com")
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+
+	t.Run("requires UseCORS", func(t *testing.T) {
+		assert.Panics(t, func() { echonext.New().DocumentCORS() })
+	})
+}
@@ -0,0 +1,58 @@
+package echonext
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RouteDefinition declares a route whose handler is looked up by name,
+// allowing route tables to be loaded from configuration
+type RouteDefinition struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Handler string `json:"handler"`
+	Route   Route  `json:"route"`
+}
+
+// RegisterFromSpec registers routes from declarative definitions, resolving
+// each handler name with resolver. All definitions are checked before any
+// route is registered, so an error leaves the app unchanged.
+func (app *App) RegisterFromSpec(definitions []RouteDefinition, resolver func(name string) interface{}) error {
+	handlers := make([]interface{}, len(definitions))
+	seen := make(map[string]RouteInfo, len(definitions))
+	for i, def := range definitions {
+		method := strings.ToUpper(def.Method)
+		switch method {
+		case "GET", "POST", "PUT", "PATCH", "DELETE":
+		default:
+			return fmt.Errorf("route %s %s: unsupported method %q", def.Method, def.Path, def.Method)
+		}
+
+		handler := resolver(def.Handler)
+		if handler == nil {
+			return fmt.Errorf("route %s %s: unknown handler %q", def.Method, def.Path, def.Handler)
+		}
+		if reflect.TypeOf(handler).Kind() != reflect.Func {
+			return fmt.Errorf("route %s %s: handler %q is not a function", def.Method, def.Path, def.Handler)
+		}
+		handlers[i] = handler
+
+		if app.duplicatePolicy != DuplicateRouteWarn {
+			existing, ok := seen[routeKey(method, def.Path)]
+			if j := app.findRoute(method, def.Path); j >= 0 {
+				existing, ok = app.routes[j], true
+			}
+			route := RouteInfo{Method: method, Path: def.Path, Handler: handler, site: registrationSite()}
+			if ok {
+				return duplicateRouteError(existing, route)
+			}
+			seen[routeKey(method, def.Path)] = route
+		}
+	}
+
+	for i, def := range definitions {
+		app.registerRoute(strings.ToUpper(def.Method), def.Path, handlers[i], nil, def.Route)
+	}
+	return nil
+}
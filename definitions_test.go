@@ -0,0 +1,68 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterFromSpec(t *testing.T) {
+	handlers := map[string]interface{}{
+		"listUsers": func(c echo.Context) ([]TestUser, error) {
+			return []TestUser{{ID: "1", Name: "John"}}, nil
+		},
+		"createUser": func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+			return TestUser{ID: "2", Name: req.Name, Email: req.Email}, nil
+		},
+	}
+	resolver := func(name string) interface{} {
+		return handlers[name]
+	}
+
+	var definitions []echonext.RouteDefinition
+	err := json.Unmarshal([]byte(`[
+		{"method": "GET", "path": "/users", "handler": "listUsers", "route": {"Summary": "List users"}},
+		{"method": "POST", "path": "/users", "handler": "createUser", "route": {"SuccessStatus": 201}}
+	]`), &definitions)
+	assert.NoError(t, err)
+
+	app := echonext.New()
+	assert.NoError(t, app.RegisterFromSpec(definitions, resolver))
+
+	t.Run("list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		body, _ := json.Marshal(CreateUserRequest{Name: "Jane", Email: "jane@example.com"})
+		req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		assert.Equal(t, "List users", spec.Paths["/users"].Get.Summary)
+	})
+
+	t.Run("unknown handler", func(t *testing.T) {
+		other := echonext.New()
+		err := other.RegisterFromSpec([]echonext.RouteDefinition{
+			{Method: "GET", Path: "/users", Handler: "listUsers"},
+			{Method: "GET", Path: "/orders", Handler: "listOrders"},
+		}, resolver)
+		assert.EqualError(t, err, `route GET /orders: unknown handler "listOrders"`)
+		assert.Empty(t, other.GenerateOpenAPISpec().Paths)
+	})
+}
@@ -0,0 +1,76 @@
+package echonext
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// Deprecation response headers
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+// isDeprecated reports whether a route is deprecated; a sunset date implies it
+func (route *Route) isDeprecated() bool {
+	return route != nil && (route.Deprecated || route.Sunset != nil)
+}
+
+// deprecate sets the Deprecation header, and the Sunset header when the route
+// has a sunset date, on every response of a deprecated route
+func deprecate(route *Route, next echo.HandlerFunc) echo.HandlerFunc {
+	if !route.isDeprecated() {
+		return next
+	}
+	sunset := ""
+	if route.Sunset != nil {
+		sunset = route.Sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c echo.Context) error {
+		header := c.Response().Header()
+		header.Set(HeaderDeprecation, "true")
+		if sunset != "" {
+			header.Set(HeaderSunset, sunset)
+		}
+		return next(c)
+	}
+}
+
+// deprecationNote returns the description of a deprecated route, noting the
+// deprecation and sunset date
+func deprecationNote(description string, route *Route) string {
+	note := "@deprecated"
+	if route.Sunset != nil {
+		note += " Removed after " + route.Sunset.UTC().Format(time.DateOnly) + "."
+	}
+	if description == "" {
+		return note
+	}
+	return description + "\n\n" + note
+}
+
+// deprecationHeaders documents the headers sent by a deprecated route
+func deprecationHeaders(route *Route) openapi3.Headers {
+	header := func(description string) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{
+			Value: &openapi3.Header{
+				Parameter: openapi3.Parameter{
+					Description: description,
+					Schema: &openapi3.SchemaRef{
+						Value: &openapi3.Schema{Type: "string"},
+					},
+				},
+			},
+		}
+	}
+	headers := openapi3.Headers{
+		HeaderDeprecation: header("Present while the operation is deprecated"),
+	}
+	if route.Sunset != nil {
+		headers[HeaderSunset] = header("HTTP date after which the operation will be removed")
+	}
+	return headers
+}
@@ -0,0 +1,68 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedRoutes(t *testing.T) {
+	handler := func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil }
+	sunset := time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	app := echonext.New()
+	app.GET("/v1/users", handler, echonext.Route{Description: "Lists users", Deprecated: true})
+	app.GET("/v1/orders", handler, echonext.Route{Sunset: &sunset})
+	app.GET("/v2/users", handler)
+	app.GET("/v1/admin", handler, echonext.Route{Deprecated: true, Middleware: []echo.MiddlewareFunc{
+		func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		},
+	}})
+
+	spec := app.GenerateOpenAPISpec()
+	users := spec.Paths["/v1/users"].Get
+	assert.True(t, users.Deprecated)
+	assert.Equal(t, "Lists users\n\n@deprecated", users.Description)
+	assert.Contains(t, users.Responses["200"].Value.Headers, echonext.HeaderDeprecation)
+	assert.NotContains(t, users.Responses["200"].Value.Headers, echonext.HeaderSunset)
+
+	orders := spec.Paths["/v1/orders"].Get
+	assert.True(t, orders.Deprecated, "a sunset date implies deprecation")
+	assert.Equal(t, "@deprecated Removed after 2027-03-31.", orders.Description)
+	assert.Contains(t, orders.Responses["200"].Value.Headers, echonext.HeaderSunset)
+
+	current := spec.Paths["/v2/users"].Get
+	assert.False(t, current.Deprecated)
+	assert.Empty(t, current.Description)
+
+	t.Run("headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/orders", nil))
+		assert.Equal(t, http.StatusOK, rec.Code, "deprecated routes are still served")
+		assert.Equal(t, "true", rec.Header().Get(echonext.HeaderDeprecation))
+		assert.Equal(t, "Wed, 31 Mar 2027 00:00:00 GMT", rec.Header().Get(echonext.HeaderSunset))
+
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+		assert.Equal(t, "true", rec.Header().Get(echonext.HeaderDeprecation))
+		assert.Empty(t, rec.Header().Get(echonext.HeaderSunset))
+
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/users", nil))
+		assert.Empty(t, rec.Header().Get(echonext.HeaderDeprecation))
+
+		// Responses from route middleware are marked too
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/admin", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get(echonext.HeaderDeprecation))
+	})
+}
@@ -0,0 +1,128 @@
+package echonext
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Context keys for dependencies
+const (
+	depsKey         = "echonext.deps"
+	depProvidersKey = "echonext.providers"
+)
+
+// depProvider resolves a dependency for a request
+type depProvider func(c echo.Context) (interface{}, error)
+
+// Provide registers how to resolve dependencies of type T, such as a
+// database handle or the authenticated user. It is called at most once per
+// request, the first time the dependency is needed:
+//
+//	echonext.Provide(app, func(c echo.Context) (*User, error) {
+//		return users.FromToken(c.Request().Header.Get("Authorization"))
+//	})
+func Provide[T any](app *App, resolve func(c echo.Context) (T, error)) {
+	if resolve == nil {
+		panic("echonext: dependency provider must not be nil")
+	}
+	if app.providers == nil {
+		app.providers = make(map[reflect.Type]depProvider)
+	}
+	app.providers[typeOf[T]()] = func(c echo.Context) (interface{}, error) {
+		return resolve(c)
+	}
+}
+
+// SetDeps sets the dependency of type T for the request, e.g. from
+// middleware. It takes precedence over a provider.
+func SetDeps[T any](c echo.Context, value T) {
+	requestDeps(c)[typeOf[T]()] = value
+}
+
+// Deps returns the request's dependency of type T, set with SetDeps or
+// resolved by the provider registered with Provide
+func Deps[T any](c echo.Context) (T, error) {
+	value, err := resolveDeps(c, typeOf[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	// Nil interface dependencies are stored as untyped nils, which fail to assert
+	deps, _ := value.(T)
+	return deps, nil
+}
+
+// AppContext is an echo.Context carrying the request's dependencies. Handlers
+// may take it in place of echo.Context; D is resolved before the handler runs:
+//
+//	func listTodos(c echonext.AppContext[*Services], req ListTodosRequest) ([]Todo, error) {
+//		return c.Deps.Todos.List(c.Request().Context(), req)
+//	}
+type AppContext[D any] struct {
+	echo.Context
+	Deps D
+}
+
+// contextBuilder builds the AppContext a handler takes
+type contextBuilder interface {
+	depsType() reflect.Type
+	build(c echo.Context, deps interface{}) interface{}
+}
+
+func (AppContext[D]) depsType() reflect.Type {
+	return typeOf[D]()
+}
+
+func (AppContext[D]) build(c echo.Context, deps interface{}) interface{} {
+	value, _ := deps.(D)
+	return AppContext[D]{Context: c, Deps: value}
+}
+
+// contextBuilderFor returns the builder for handlers taking t, or nil for
+// echo.Context. Other types panic.
+func contextBuilderFor(t reflect.Type) contextBuilder {
+	if t == contextType {
+		return nil
+	}
+	if t.Kind() == reflect.Struct {
+		if builder, ok := reflect.Zero(t).Interface().(contextBuilder); ok {
+			return builder
+		}
+	}
+	panic(fmt.Sprintf("echonext: handler's first parameter must be echo.Context or echonext.AppContext, not %s", t))
+}
+
+// resolveDeps returns the request's dependency of type t, resolving and
+// keeping it on first use
+func resolveDeps(c echo.Context, t reflect.Type) (interface{}, error) {
+	deps := requestDeps(c)
+	if value, ok := deps[t]; ok {
+		return value, nil
+	}
+	providers, _ := c.Get(depProvidersKey).(map[reflect.Type]depProvider)
+	provide, ok := providers[t]
+	if !ok {
+		return nil, fmt.Errorf("echonext: no %s provided for this request", t)
+	}
+	value, err := provide(c)
+	if err != nil {
+		return nil, err
+	}
+	deps[t] = value
+	return value, nil
+}
+
+func requestDeps(c echo.Context) map[reflect.Type]interface{} {
+	deps, ok := c.Get(depsKey).(map[reflect.Type]interface{})
+	if !ok {
+		deps = make(map[reflect.Type]interface{})
+		c.Set(depsKey, deps)
+	}
+	return deps
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
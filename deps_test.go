@@ -0,0 +1,136 @@
+package echonext_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Services struct {
+	Greeting string
+}
+
+type CurrentUser struct {
+	Name string
+}
+
+func TestDependencies(t *testing.T) {
+	app := echonext.New()
+	resolved := 0
+	echonext.Provide(app, func(c echo.Context) (*Services, error) {
+		resolved++
+		return &Services{Greeting: "Hello"}, nil
+	})
+	echonext.Provide(app, func(c echo.Context) (CurrentUser, error) {
+		token := c.Request().Header.Get(echo.HeaderAuthorization)
+		if token == "" {
+			return CurrentUser{}, echo.NewHTTPError(http.StatusUnauthorized, "sign in first")
+		}
+		return CurrentUser{Name: strings.TrimPrefix(token, "Bearer ")}, nil
+	})
+
+	app.POST("/greetings", func(c echonext.AppContext[*Services], req CreateUserRequest) (TestUser, error) {
+		again, err := echonext.Deps[*Services](c)
+		if err != nil || again != c.Deps {
+			return TestUser{}, errors.New("dependency resolved twice")
+		}
+		return TestUser{Name: c.Deps.Greeting + ", " + req.Name}, nil
+	})
+	app.GET("/me", func(c echonext.AppContext[CurrentUser]) (TestUser, error) {
+		return TestUser{Name: c.Deps.Name}, nil
+	})
+	app.GET("/plain", func(c echo.Context) (TestUser, error) {
+		user, err := echonext.Deps[CurrentUser](c)
+		if err != nil {
+			return TestUser{}, err
+		}
+		return TestUser{Name: user.Name}, nil
+	})
+	app.GET("/unprovided", func(c echonext.AppContext[*strings.Builder]) (TestUser, error) {
+		return TestUser{Name: "unreachable"}, nil
+	})
+
+	// Middleware may set dependencies itself
+	app.GET("/impersonate", func(c echonext.AppContext[CurrentUser]) (TestUser, error) {
+		return TestUser{Name: c.Deps.Name}, nil
+	}, echonext.Route{Middleware: []echo.MiddlewareFunc{func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			echonext.SetDeps(c, CurrentUser{Name: "admin"})
+			return next(c)
+		}
+	}}})
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPost, "/greetings", "", `{"name":"Ada","email":"ada@example.com"}`)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"name":"Hello, Ada"`)
+	assert.Equal(t, 1, resolved)
+
+	// Invalid requests are rejected before dependencies are resolved
+	rec = serve(http.MethodPost, "/greetings", "", `{"name":""}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 1, resolved)
+
+	assert.Contains(t, serve(http.MethodGet, "/me", "ada", "").Body.String(), `"name":"ada"`)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/me", "", "").Code)
+	assert.Contains(t, serve(http.MethodGet, "/plain", "grace", "").Body.String(), `"name":"grace"`)
+	assert.Contains(t, serve(http.MethodGet, "/impersonate", "", "").Body.String(), `"name":"admin"`)
+
+	rec = serve(http.MethodGet, "/unprovided", "", "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no *strings.Builder provided")
+
+	// AppContext handlers are documented like any other
+	assert.NotNil(t, app.GenerateOpenAPISpec().Paths["/greetings"].Post.RequestBody)
+
+	assert.PanicsWithValue(t, "echonext: handler's first parameter must be echo.Context or echonext.AppContext, not string", func() {
+		app.GET("/broken", func(s string) (TestUser, error) { return TestUser{}, nil })
+	})
+}
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+func TestNilInterfaceDependencies(t *testing.T) {
+	app := echonext.New()
+	echonext.Provide(app, func(c echo.Context) (Greeter, error) {
+		return nil, nil
+	})
+	app.GET("/provided", func(c echonext.AppContext[Greeter]) (TestUser, error) {
+		return TestUser{Name: fmt.Sprint(c.Deps == nil)}, nil
+	})
+	app.GET("/set", func(c echo.Context) (TestUser, error) {
+		greeter, err := echonext.Deps[Greeter](c)
+		return TestUser{Name: fmt.Sprint(greeter == nil)}, err
+	}, echonext.Route{Middleware: []echo.MiddlewareFunc{func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			echonext.SetDeps[Greeter](c, nil)
+			return next(c)
+		}
+	}}})
+
+	for _, path := range []string{"/provided", "/set"} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Contains(t, rec.Body.String(), `"name":"true"`, path)
+	}
+}
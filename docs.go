@@ -0,0 +1,119 @@
+package echonext
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+// LoadDocComments registers handler doc comments keyed by handler name
+// ("createTodo", or "Server.createTodo" for methods). Routes without an
+// explicit Summary or Description are documented from these comments.
+// The map is typically generated by the echonext-doc command.
+func (app *App) LoadDocComments(comments map[string]string) {
+	if app.docComments == nil {
+		app.docComments = make(map[string]string, len(comments))
+	}
+	for name, doc := range comments {
+		app.docComments[name] = doc
+	}
+}
+
+// ParseDocComments parses the Go files in dir and returns the doc comments of
+// its functions and methods keyed by handler name. The leading function name
+// that Go doc comments conventionally start with is dropped.
+func ParseDocComments(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	filter := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+	pkgs, err := parser.ParseDir(fset, dir, filter, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Doc == nil {
+					continue
+				}
+				name := fn.Name.Name
+				if fn.Recv != nil && len(fn.Recv.List) > 0 {
+					name = receiverName(fn.Recv.List[0].Type) + "." + name
+				}
+				if doc := trimDocName(fn.Doc.Text(), fn.Name.Name); doc != "" {
+					comments[name] = doc
+				}
+			}
+		}
+	}
+	return comments, nil
+}
+
+// receiverName returns the base type name of a method receiver expression
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// trimDocName drops a leading "name " from doc and capitalizes the remainder
+func trimDocName(doc, name string) string {
+	doc = strings.TrimSpace(doc)
+	if rest, ok := strings.CutPrefix(doc, name+" "); ok {
+		doc = rest
+		if doc != "" {
+			r := []rune(doc)
+			r[0] = unicode.ToUpper(r[0])
+			doc = string(r)
+		}
+	}
+	return doc
+}
+
+// docSummary returns the first sentence of a doc comment
+func docSummary(doc string) string {
+	line, _, _ := strings.Cut(doc, "\n\n")
+	line = strings.Join(strings.Fields(line), " ")
+	if i := strings.Index(line, ". "); i >= 0 {
+		line = line[:i+1]
+	}
+	return line
+}
+
+// handlerName returns the short name of a handler function as used for doc
+// comment lookups, e.g. "createTodo" or "Server.createTodo"
+func handlerName(handler interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+
+	// Drop the package path and package name
+	name = name[strings.LastIndex(name, "/")+1:]
+	if dot := strings.Index(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	// Method values look like "(*Server).createTodo-fm"
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+	return name
+}
@@ -0,0 +1,59 @@
+package echonext_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// getWidget returns a widget by its ID.
+//
+// Widgets are looked up in the primary store.
+func getWidget(c echo.Context) (TestUser, error) {
+	return TestUser{ID: c.Param("id")}, nil
+}
+
+func TestLoadDocComments(t *testing.T) {
+	app := echonext.New()
+	app.LoadDocComments(map[string]string{
+		"getWidget": "Returns a widget by its ID.\n\nWidgets are looked up in the primary store.",
+	})
+
+	app.GET("/widgets/:id", getWidget)
+	app.GET("/widgets", getWidget, echonext.Route{Summary: "List widgets"})
+
+	spec := app.GenerateOpenAPISpec()
+
+	get := spec.Paths["/widgets/{id}"].Get
+	assert.Equal(t, "Returns a widget by its ID.", get.Summary)
+	assert.Contains(t, get.Description, "primary store")
+
+	list := spec.Paths["/widgets"].Get
+	assert.Equal(t, "List widgets", list.Summary)
+}
+
+func TestParseDocComments(t *testing.T) {
+	dir := t.TempDir()
+	src := `package api
+
+// createTodo creates a new todo.
+func createTodo() {}
+
+// list returns todos.
+func (s *Server) list() {}
+
+func undocumented() {}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "handlers.go"), []byte(src), 0o644))
+
+	comments, err := echonext.ParseDocComments(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"createTodo":  "Creates a new todo.",
+		"Server.list": "Returns todos.",
+	}, comments)
+}
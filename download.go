@@ -0,0 +1,78 @@
+package echonext
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// FileResponse is a handler result streamed as a file download instead of an
+// enveloped response. Readers that are also io.Closers are closed once sent.
+type FileResponse struct {
+	Reader        io.Reader
+	ContentType   string // Defaults to application/octet-stream
+	Filename      string // Offered as the download's name when set
+	ContentLength int64  // Sent as Content-Length when positive
+	Inline        bool   // Asks browsers to display the file rather than save it
+}
+
+var fileResponseType = reflect.TypeOf(FileResponse{})
+
+// sendFile streams a file response with its content headers
+func sendFile(c echo.Context, status int, file FileResponse) error {
+	if closer, ok := file.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if file.Reader == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	header := c.Response().Header()
+	disposition := "attachment"
+	if file.Inline {
+		disposition = "inline"
+	}
+	if file.Filename != "" {
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": file.Filename}))
+	} else if !file.Inline {
+		header.Set(echo.HeaderContentDisposition, disposition)
+	}
+	if file.ContentLength > 0 {
+		header.Set(echo.HeaderContentLength, strconv.FormatInt(file.ContentLength, 10))
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	return c.Stream(status, contentType, file.Reader)
+}
+
+// fileResponse documents a file download
+func fileResponse() *openapi3.Response {
+	return &openapi3.Response{
+		Description: strPtr("File download"),
+		Headers: openapi3.Headers{
+			echo.HeaderContentDisposition: &openapi3.HeaderRef{
+				Value: &openapi3.Header{
+					Parameter: openapi3.Parameter{
+						Description: "Whether to save or display the file, and its name",
+						Schema: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{Type: "string", Example: `attachment; filename="report.csv"`},
+						},
+					},
+				},
+			},
+		},
+		Content: openapi3.Content{
+			echo.MIMEOctetStream: &openapi3.MediaType{
+				Schema: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string", Format: "binary"}},
+			},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package echonext_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type trackedReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackedReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestFileResponses(t *testing.T) {
+	report := &trackedReader{Reader: strings.NewReader("id,name\n1,Ada\n")}
+
+	app := echonext.New()
+	app.GET("/reports/:id", func(c echo.Context) (echonext.FileResponse, error) {
+		if c.Param("id") == "missing" {
+			return echonext.FileResponse{}, echo.NewHTTPError(http.StatusNotFound, "report not found")
+		}
+		return echonext.FileResponse{
+			Reader:        report,
+			ContentType:   "text/csv",
+			Filename:      "users 2024.csv",
+			ContentLength: 14,
+		}, nil
+	})
+	app.GET("/preview", func(c echo.Context) (echonext.FileResponse, error) {
+		return echonext.FileResponse{Reader: strings.NewReader("%PDF"), ContentType: "application/pdf", Inline: true}, nil
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(echo.HeaderAccept, "text/csv")
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/reports/1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="users 2024.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "14", rec.Header().Get(echo.HeaderContentLength))
+	assert.Equal(t, "id,name\n1,Ada\n", rec.Body.String())
+	assert.True(t, report.closed)
+
+	rec = serve("/preview")
+	assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "%PDF", rec.Body.String())
+
+	rec = serve("/reports/missing")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "report not found")
+
+	response := app.GenerateOpenAPISpec().Paths["/reports/{id}"].Get.Responses["200"].Value
+	schema := response.Content[echo.MIMEOctetStream].Schema.Value
+	assert.Equal(t, "string", schema.Type)
+	assert.Equal(t, "binary", schema.Format)
+	assert.Contains(t, response.Headers, echo.HeaderContentDisposition)
+	assert.NoError(t, app.ValidateSpec())
+}
@@ -0,0 +1,88 @@
+package echonext
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// DuplicateRoutePolicy controls how registering the same method and path twice is handled
+type DuplicateRoutePolicy int
+
+const (
+	// DuplicateRouteError panics on duplicate registrations (default)
+	DuplicateRouteError DuplicateRoutePolicy = iota
+	// DuplicateRouteWarn logs a warning and lets the later handler replace the earlier one
+	DuplicateRouteWarn
+)
+
+// SetDuplicateRoutePolicy sets how duplicate method+path registrations are handled
+func (app *App) SetDuplicateRoutePolicy(policy DuplicateRoutePolicy) {
+	app.duplicatePolicy = policy
+}
+
+// findRoute returns the index of the enabled route registered for method and
+// path, or -1. Paths differing only in parameter names are the same Echo route.
+func (app *App) findRoute(method, path string) int {
+	key := routeKey(method, path)
+	for i, route := range app.routes {
+		if route.isEnabled() && routeKey(route.Method, route.Path) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// duplicateRouteError describes a registration that conflicts with an existing route
+func duplicateRouteError(existing, route RouteInfo) error {
+	return fmt.Errorf("echonext: duplicate route %s %s: handler %s%s conflicts with %s registered for %s%s",
+		route.Method, route.Path, describeHandler(route.Handler), atSite(route.site),
+		describeHandler(existing.Handler), existing.Path, atSite(existing.site))
+}
+
+// packagePrefix prefixes the names of this package's functions in stack frames
+var packagePrefix = reflect.TypeOf(App{}).PkgPath() + "."
+
+// registrationSite returns the file:line of the code registering a route:
+// the first caller outside this package
+func registrationSite() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// atSite formats a registration site for error messages
+func atSite(site string) string {
+	if site == "" {
+		return ""
+	}
+	return " at " + site
+}
+
+// routeKey normalizes parameter names so /users/:id and /users/:userId match
+func routeKey(method, path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = ":"
+		}
+	}
+	return method + " " + strings.Join(parts, "/")
+}
+
+func describeHandler(handler interface{}) string {
+	if name := handlerName(handler); name != "" {
+		return name
+	}
+	return "<unknown>"
+}
@@ -0,0 +1,60 @@
+package echonext_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTodo(c echo.Context) (TestUser, error)   { return TestUser{ID: "first"}, nil }
+func createTodoV2(c echo.Context) (TestUser, error) { return TestUser{ID: "second"}, nil }
+
+func TestDuplicateRoutes(t *testing.T) {
+	t.Run("error by default", func(t *testing.T) {
+		app := echonext.New()
+		app.POST("/todos", createTodo)
+		assert.PanicsWithValue(t,
+			"echonext: duplicate route POST /todos: handler createTodoV2 at duplicates_test.go:24 conflicts with createTodo registered for /todos at duplicates_test.go:21",
+			func() { app.POST("/todos", createTodoV2) })
+	})
+
+	t.Run("parameter names ignored", func(t *testing.T) {
+		app := echonext.New()
+		app.GET("/todos/:id", createTodo)
+		assert.Panics(t, func() { app.GET("/todos/:todoId", createTodoV2) })
+		assert.NotPanics(t, func() { app.DELETE("/todos/:todoId", createTodoV2) })
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		app := echonext.New()
+		var logs bytes.Buffer
+		app.Logger.SetOutput(&logs)
+		app.Logger.SetLevel(log.WARN)
+		app.SetDuplicateRoutePolicy(echonext.DuplicateRouteWarn)
+
+		app.POST("/todos", createTodo)
+		app.POST("/todos", createTodoV2)
+		assert.Contains(t, logs.String(), "duplicate route POST /todos")
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/todos", nil))
+		assert.Contains(t, rec.Body.String(), `"id":"second"`)
+	})
+
+	t.Run("declarative definitions", func(t *testing.T) {
+		app := echonext.New()
+		err := app.RegisterFromSpec([]echonext.RouteDefinition{
+			{Method: "POST", Path: "/todos", Handler: "createTodo"},
+			{Method: "POST", Path: "/todos", Handler: "createTodoV2"},
+		}, func(name string) interface{} {
+			return map[string]interface{}{"createTodo": createTodo, "createTodoV2": createTodoV2}[name]
+		})
+		assert.EqualError(t, err, "echonext: duplicate route POST /todos: handler createTodoV2 at duplicates_test.go:52 conflicts with createTodo registered for /todos at duplicates_test.go:52")
+	})
+}
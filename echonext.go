@@ -2,15 +2,27 @@
 package echonext
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 )
 
 // App represents an EchoNext application
@@ -19,6 +31,55 @@ type App struct {
 	spec      *openapi3.T
 	validator *validator.Validate
 	routes    []RouteInfo
+	intEnums  map[reflect.Type]intEnum
+	enums     map[reflect.Type][]interface{}
+	providers map[reflect.Type]depProvider
+	ready     atomic.Bool
+
+	docComments  map[string]string
+	cors         *middleware.CORSConfig
+	documentCORS bool
+
+	streamingJSON   bool
+	envelope        Envelope
+	duplicatePolicy DuplicateRoutePolicy
+	scopeResolver   func(c echo.Context) []string
+	exampleProvider func(t reflect.Type) (interface{}, bool)
+
+	handlerTimeout      time.Duration
+	maxBodyBytes        int64
+	contentTypeTimeouts map[string]time.Duration
+	autoTags            bool
+	warnedTags          map[string]bool
+
+	maxURLLength      int
+	urlLimitInstalled bool
+
+	tagSchemas       map[string]func(s *openapi3.Schema)
+	documentDisabled bool
+	schemaCache      map[reflect.Type]*openapi3.Schema
+	routeNames       map[string]string
+	componentPrefix  string
+	specProcessors   []func(spec *openapi3.T)
+
+	operationIDStrategy OperationIDStrategy
+	naming              NamingStrategy
+
+	globalSecurity  []Security
+	enforceSecurity bool
+	authenticator   func(c echo.Context, scheme Security) error
+	errorMapper     func(err error) (int, string, bool)
+	recoverPanics   bool
+	requestLogger   func(c echo.Context, entry RequestLog)
+	metrics         metricsRegistry
+	metricsEnabled  bool
+
+	inlineSchemas  bool
+	inlining       map[reflect.Type]bool
+	componentNames map[reflect.Type]string
+
+	specMu        sync.Mutex        // Serializes spec generation, which fills the schema cache
+	validatedSpec [sha256.Size]byte // Digest of the last spec served, validated once
 }
 
 // RouteInfo stores metadata about a route for OpenAPI generation
@@ -32,6 +93,8 @@ type RouteInfo struct {
 	RequestType  reflect.Type
 	ResponseType reflect.Type
 	RouteConfig  *Route // Store the full route configuration
+
+	site string // file:line of the registration, for error messages
 }
 
 // Route configures route metadata for OpenAPI generation
@@ -44,15 +107,34 @@ type Route struct {
 	RequestHeaders  map[string]HeaderInfo
 	ResponseHeaders map[string]HeaderInfo
 	ContentTypes    []string
+	ContentSchemas  map[string]interface{} // Request body type per content type; []byte bodies are passed through unread
 	Examples        map[string]interface{}
+	RateLimit       *RateLimit             // Limit requests per client; nil disables limiting
+	OptionalBody    bool                   // Accept requests without a body
+	Coalesce        bool                   // Run identical concurrent requests once and share the response
+	Features        []string               // Feature flags that change the response, documented as x-feature-flags
+	Timeout         *time.Duration         // Overrides the handler timeout; zero disables it
+	Enabled         *bool                  // Set to false to register the route without serving it; nil means enabled
+	Name            string                 // Name for building URLs with app.URL
+	Middleware      []echo.MiddlewareFunc  // Runs before binding and validation, after group middleware
+	Statuses        []int                  // Other success statuses a (T, int, error) handler returns, for the spec
+	OperationID     string                 // Pins the operationId instead of deriving it from the handler
+	Public          bool                   // Exempts the route from the global security requirement
+	Deprecated      bool                   // Marks the operation deprecated; it is still served
+	Sunset          *time.Time             // When the route will be removed; implies Deprecated and sets the Sunset header
+	Responses       map[int]ResponseSpec   // Documents further statuses, or overrides the default ones, by status
+	MaxBodyBytes    int64                  // Overrides the app's body size limit; negative disables it
+	Extensions      map[string]interface{} // Vendor extensions on the operation, e.g. x-internal; keys start with x-
 }
 
 // Security defines security requirements for a route
 type Security struct {
-	Type   string // "bearer", "apiKey", "oauth2", "basic"
-	Name   string // For apiKey: header/query/cookie name
-	Scheme string // For bearer: "bearer", for basic: "basic"
-	In     string // For apiKey: "header", "query", "cookie"
+	Type   string      // "bearer", "apiKey", "oauth2", "basic"
+	Name   string      // For apiKey: header/query/cookie name; for oauth2: the scheme name, defaulting to "oauth2"
+	Scheme string      // For bearer: "bearer", for basic: "basic"
+	In     string      // For apiKey: "header", "query", "cookie"
+	Flows  *OAuthFlows // For oauth2 schemes: the supported flows
+	Scopes []string    // For oauth2 requirements: the scopes the route needs
 }
 
 // HeaderInfo describes a header parameter
@@ -83,16 +165,20 @@ type License struct {
 
 // Response wraps API responses with a standard structure
 type Response[T any] struct {
-	Data    T      `json:"data,omitempty"`
-	Error   string `json:"error,omitempty"`
-	Success bool   `json:"success"`
+	XMLName       xml.Name     `json:"-" xml:"response"`
+	Data          T            `json:"data,omitempty" xml:"data,omitempty"`
+	Error         string       `json:"error,omitempty" xml:"error,omitempty"`
+	Success       bool         `json:"success" xml:"success"`
+	CorrelationID string       `json:"correlation_id,omitempty" xml:"correlation_id,omitempty"`
+	Details       []FieldError `json:"details,omitempty" xml:"detail,omitempty"`
+	Warnings      []string     `json:"warnings,omitempty" xml:"warning,omitempty"`
 }
 
 // New creates a new EchoNext application
 func New() *App {
 	e := echo.New()
 	spec := &openapi3.T{
-		OpenAPI: "3.0.0",
+		OpenAPI: OpenAPI30,
 		Info: &openapi3.Info{
 			Title:   "API",
 			Version: "1.0.0",
@@ -103,11 +189,36 @@ func New() *App {
 		},
 	}
 
-	return &App{
+	app := &App{
 		Echo:      e,
 		spec:      spec,
 		validator: validator.New(),
 		routes:    []RouteInfo{},
+		envelope:  StandardEnvelope{},
+
+		operationIDStrategy: OperationIDHandlerNameWithSuffix,
+	}
+	e.Pre(app.provideAppValues)
+	return app
+}
+
+// provideAppValues makes the app's envelope, dependency providers and error
+// mapper available to code without the App, such as error responses written
+// by rate limiting and timeouts, Deps and typed middleware. A single
+// middleware keeps the per-request cost to one closure.
+func (app *App) provideAppValues(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		// The standard envelope is the fallback, so it needs no context entry
+		if _, standard := app.envelope.(StandardEnvelope); !standard {
+			c.Set(envelopeKey, app.envelope)
+		}
+		if len(app.providers) > 0 {
+			c.Set(depProvidersKey, app.providers)
+		}
+		if app.errorMapper != nil {
+			c.Set(errorMapperKey, app.errorMapper)
+		}
+		return next(c)
 	}
 }
 
@@ -148,6 +259,40 @@ func (app *App) SetServers(servers []Server) {
 	}
 }
 
+// SetGlobalSecurity sets the security requirements of every operation that
+// declares none. Mark routes Public to exempt them.
+func (app *App) SetGlobalSecurity(security []Security) {
+	app.globalSecurity = security
+	app.spec.Security = securityRequirements(security)
+}
+
+// securityRequirements converts route security to OpenAPI requirements,
+// naming bearer and basic schemes bearerAuth and basicAuth
+func securityRequirements(security []Security) openapi3.SecurityRequirements {
+	requirements := make(openapi3.SecurityRequirements, 0, len(security))
+	for _, sec := range security {
+		secReq := openapi3.SecurityRequirement{}
+		switch sec.Type {
+		case "bearer":
+			secReq["bearerAuth"] = []string{}
+		case "apiKey":
+			if sec.Name != "" {
+				secReq[sec.Name] = []string{}
+			}
+		case "basic":
+			secReq["basicAuth"] = []string{}
+		case "oauth2":
+			name := sec.Name
+			if name == "" {
+				name = "oauth2"
+			}
+			secReq[name] = append([]string{}, sec.Scopes...)
+		}
+		requirements = append(requirements, secReq)
+	}
+	return requirements
+}
+
 // AddSecurityScheme adds a security scheme to the OpenAPI spec
 func (app *App) AddSecurityScheme(name string, security Security) {
 	if app.spec.Components.SecuritySchemes == nil {
@@ -171,8 +316,12 @@ func (app *App) AddSecurityScheme(name string, security Security) {
 		scheme.Type = "http"
 		scheme.Scheme = "basic"
 	case "oauth2":
+		flows, err := oauthFlows(security.Flows)
+		if err != nil {
+			panic(fmt.Sprintf("echonext: security scheme %q: %v", name, err))
+		}
 		scheme.Type = "oauth2"
-		// OAuth2 flows would need additional configuration
+		scheme.Flows = flows
 	}
 
 	app.spec.Components.SecuritySchemes[name] = &openapi3.SecuritySchemeRef{
@@ -182,31 +331,32 @@ func (app *App) AddSecurityScheme(name string, security Security) {
 
 // GET registers a typed GET endpoint
 func (app *App) GET(path string, handler interface{}, opts ...Route) {
-	app.registerRoute("GET", path, handler, opts...)
+	app.registerRoute("GET", path, handler, nil, opts...)
 }
 
 // POST registers a typed POST endpoint
 func (app *App) POST(path string, handler interface{}, opts ...Route) {
-	app.registerRoute("POST", path, handler, opts...)
+	app.registerRoute("POST", path, handler, nil, opts...)
 }
 
 // PUT registers a typed PUT endpoint
 func (app *App) PUT(path string, handler interface{}, opts ...Route) {
-	app.registerRoute("PUT", path, handler, opts...)
+	app.registerRoute("PUT", path, handler, nil, opts...)
 }
 
 // PATCH registers a typed PATCH endpoint
 func (app *App) PATCH(path string, handler interface{}, opts ...Route) {
-	app.registerRoute("PATCH", path, handler, opts...)
+	app.registerRoute("PATCH", path, handler, nil, opts...)
 }
 
 // DELETE registers a typed DELETE endpoint
 func (app *App) DELETE(path string, handler interface{}, opts ...Route) {
-	app.registerRoute("DELETE", path, handler, opts...)
+	app.registerRoute("DELETE", path, handler, nil, opts...)
 }
 
-// registerRoute registers a route with type information
-func (app *App) registerRoute(method, path string, handler interface{}, opts ...Route) {
+// registerRoute registers a route with type information. mw runs
+// before the typed handler's binding and validation.
+func (app *App) registerRoute(method, path string, handler interface{}, mw []echo.MiddlewareFunc, opts ...Route) {
 	handlerType := reflect.TypeOf(handler)
 	if handlerType.Kind() != reflect.Func {
 		panic("handler must be a function")
@@ -217,9 +367,12 @@ func (app *App) registerRoute(method, path string, handler interface{}, opts ...
 	if handlerType.NumIn() > 1 {
 		requestType = handlerType.In(1)
 	}
-	if handlerType.NumOut() > 0 {
+	if handlerType.NumOut() > 0 && handlerType.Out(0) != errorType {
 		responseType = handlerType.Out(0)
 	}
+	if handlerType.NumOut() == 3 && (handlerType.Out(1).Kind() != reflect.Int || handlerType.Out(2) != errorType) {
+		panic("handler returning three values must return (T, int, error)")
+	}
 
 	// Store route info for OpenAPI generation
 	routeInfo := RouteInfo{
@@ -228,6 +381,7 @@ func (app *App) registerRoute(method, path string, handler interface{}, opts ...
 		Handler:      handler,
 		RequestType:  requestType,
 		ResponseType: responseType,
+		site:         registrationSite(),
 	}
 
 	if len(opts) > 0 {
@@ -236,112 +390,342 @@ func (app *App) registerRoute(method, path string, handler interface{}, opts ...
 		routeInfo.Description = route.Description
 		routeInfo.Tags = route.Tags
 		routeInfo.RouteConfig = &route
+		checkResponses(route.Responses)
+		checkExtensions(route.Extensions)
+		checkContentSchemas(route.ContentSchemas, requestType)
+		checkRateLimit(route.RateLimit)
 	}
 
+	// Catch copy-pasted registrations that Echo would silently override
+	if i := app.findRoute(method, path); i >= 0 && routeInfo.isEnabled() {
+		err := duplicateRouteError(app.routes[i], routeInfo)
+		if app.duplicatePolicy != DuplicateRouteWarn {
+			panic(err.Error())
+		}
+		app.Logger.Warn(err.Error())
+		app.routes = append(app.routes[:i], app.routes[i+1:]...)
+	}
 	app.routes = append(app.routes, routeInfo)
+	if routeInfo.RouteConfig != nil && routeInfo.RouteConfig.Name != "" {
+		app.registerRouteName(routeInfo.RouteConfig.Name, path)
+	}
+
+	// Disabled routes are documented at most, never served
+	if !routeInfo.isEnabled() {
+		return
+	}
+
+	// Route middleware runs after the group's
+	if routeInfo.RouteConfig != nil && len(routeInfo.RouteConfig.Middleware) > 0 {
+		mw = append(append([]echo.MiddlewareFunc(nil), mw...), routeInfo.RouteConfig.Middleware...)
+	}
+
+	// Middleware responding early, e.g. through Middleware, follows Accept too
+	if len(mw) > 0 && len(responseTypes(routeInfo.RouteConfig)) > 1 && !isEventStream(responseType) {
+		mw = append([]echo.MiddlewareFunc{app.negotiateFormat(routeInfo.RouteConfig, responseType)}, mw...)
+	}
+
+	// Warn clients of deprecated routes on every response, including those
+	// of middleware rejecting the request
+	if routeInfo.RouteConfig.isDeprecated() {
+		config := routeInfo.RouteConfig
+		mw = append([]echo.MiddlewareFunc{func(next echo.HandlerFunc) echo.HandlerFunc {
+			return deprecate(config, next)
+		}}, mw...)
+	}
 
 	// Create Echo handler
 	echoHandler := app.createEchoHandler(handler, requestType, responseType, routeInfo.RouteConfig)
 
+	// Bound the handler by its timeout; event streams only by their own
+	if !isEventStream(responseType) || (routeInfo.RouteConfig != nil && routeInfo.RouteConfig.Timeout != nil) {
+		echoHandler = app.withTimeout(routeInfo.RouteConfig, echoHandler)
+	}
+
+	// Share responses between identical in-flight requests
+	if routeInfo.RouteConfig != nil && routeInfo.RouteConfig.Coalesce {
+		echoHandler = newCoalescer(app.coalesceVary(routeInfo.RouteConfig)).middleware(echoHandler)
+	}
+
+	// Cap the body before anything reads it
+	echoHandler = app.limitBody(routeInfo.RouteConfig, echoHandler)
+
+	// Check credentials before a response can be shared with the caller
+	echoHandler = app.authenticate(routeInfo.RouteConfig, echoHandler)
+
+	// Apply per-route rate limiting
+	if routeInfo.RouteConfig != nil && routeInfo.RouteConfig.RateLimit != nil {
+		echoHandler = newRateLimiter(*routeInfo.RouteConfig.RateLimit).middleware(echoHandler)
+	}
+
+	// Answer panics with an error envelope when enabled
+	echoHandler = app.recoverPanic(echoHandler)
+
+	// Count requests by path template once metrics are enabled
+	echoHandler = app.measure(app.metrics.route(method, openAPIPath(path)), echoHandler)
+
 	switch method {
 	case "GET":
-		app.Echo.GET(path, echoHandler)
+		app.Echo.GET(path, echoHandler, mw...)
 	case "POST":
-		app.Echo.POST(path, echoHandler)
+		app.Echo.POST(path, echoHandler, mw...)
 	case "PUT":
-		app.Echo.PUT(path, echoHandler)
+		app.Echo.PUT(path, echoHandler, mw...)
 	case "PATCH":
-		app.Echo.PATCH(path, echoHandler)
+		app.Echo.PATCH(path, echoHandler, mw...)
 	case "DELETE":
-		app.Echo.DELETE(path, echoHandler)
+		app.Echo.DELETE(path, echoHandler, mw...)
 	}
 }
 
 // createEchoHandler wraps typed handlers for Echo
 func (app *App) createEchoHandler(handler interface{}, requestType, responseType reflect.Type, routeConfig *Route) echo.HandlerFunc {
-	handlerValue := reflect.ValueOf(handler)
+	adapter := newHandlerAdapter(handler, requestType)
+	sliceParams := pathSliceFields(requestType)
+	scoped := hasScopedFields(responseType)
+	headerParams := headerFields(requestType)
+	stamped := autoFields(requestType)
+	queryFields := queryParams(requestType)
+	defaults := queryDefaults(requestType)
+	uploads := fileFields(requestType)
+	partial := keepsBody(requestType)
+	produces := responseTypes(routeConfig)
+	events := isEventStream(responseType)
+	download := responseType == fileResponseType
+	if events {
+		produces = []string{MIMETextEventStream}
+	}
 
 	return func(c echo.Context) error {
-		args := []reflect.Value{reflect.ValueOf(c)}
+		var start time.Time
+		if app.requestLogger != nil {
+			start = time.Now()
+		}
+
+		// Routes offering one type send it whatever the client accepts, and
+		// files have whatever type the handler picks, so neither is negotiated
+		offered := produces
+		if len(offered) > 1 && app.jsonOnly(responseType) {
+			offered = offered[:1]
+		}
+		format, ok := offered[0], true
+		if len(offered) > 1 {
+			format, ok = negotiate(c.Request().Header.Get(echo.HeaderAccept), offered)
+			if !ok && !download {
+				return errorResponse(c, http.StatusNotAcceptable, "Not acceptable: supported types are "+strings.Join(offered, ", "))
+			}
+		}
+		if ok && format != echo.MIMEApplicationJSON {
+			c.Set(responseFormatKey, format)
+		}
 
 		// Handle request binding if handler expects input
+		var reqPtr reflect.Value
 		if requestType != nil {
-			reqPtr := reflect.New(requestType)
+			reqPtr = adapter.acquire()
+			defer adapter.release(reqPtr)
 			req := reqPtr.Interface()
 
+			// Accept registered int enum names in place of numbers
+			if app.hasIntEnums(requestType) {
+				app.decodeIntEnumQuery(c, requestType)
+			}
+
 			// Bind based on content type and method
+			skipValidation := false
 			if c.Request().Method == "GET" || c.Request().Method == "DELETE" {
 				// Bind query parameters
 				if err := (&echo.DefaultBinder{}).BindQueryParams(c, req); err != nil {
-					return c.JSON(http.StatusBadRequest, Response[any]{
-						Error:   fmt.Sprintf("Invalid query parameters: %v", err),
-						Success: false,
-					})
+					if details := paramTypeErrors(requestType, "query", "query", c.QueryParams()); len(details) > 0 {
+						return errorResponseWithDetails(c, http.StatusBadRequest, "Invalid query parameters: "+fieldErrorsMessage(details), details)
+					}
+					return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid query parameters: %v", err))
 				}
+				if err := bindNestedQuery(c.QueryParams(), reqPtr, queryFields); err != nil {
+					return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid query parameters: %v", err))
+				}
+				applyQueryDefaults(c.QueryParams(), reqPtr, defaults)
+			} else if routeConfig != nil && routeConfig.OptionalBody && requestBodyEmpty(c.Request()) {
+				// An omitted optional body leaves the request zero-valued
+				skipValidation = true
+			} else if raw, accepted := rawRequestBody(c, routeConfig); !accepted {
+				return errorResponse(c, http.StatusUnsupportedMediaType, "Unsupported content type")
+			} else if raw {
+				// Raw bodies are left unread for the handler
+				skipValidation = true
 			} else {
+				// Keep the body so handlers can ask which fields were sent
+				if partial || c.Request().Method == http.MethodPatch {
+					if limit, tooLarge := exceededLimit(keepBody(c)); tooLarge {
+						return bodyTooLarge(c, limit)
+					}
+				}
+
+				// Fields named by the naming strategy are bound by their Go names
+				if app.naming != NamingGoField {
+					if limit, tooLarge := exceededLimit(app.decodeNamedBody(c, requestType)); tooLarge {
+						return bodyTooLarge(c, limit)
+					}
+				}
+				if app.hasIntEnums(requestType) {
+					if limit, tooLarge := exceededLimit(app.decodeIntEnumBody(c, requestType)); tooLarge {
+						return bodyTooLarge(c, limit)
+					}
+				}
+
 				// Bind JSON body for POST/PUT/PATCH
-				if err := c.Bind(req); err != nil {
-					return c.JSON(http.StatusBadRequest, Response[any]{
-						Error:   fmt.Sprintf("Invalid request body: %v", err),
-						Success: false,
-					})
+				if err := withoutPathParams(c, sliceParams, func() error { return c.Bind(req) }); err != nil {
+					if limit, tooLarge := exceededLimit(err); tooLarge {
+						return bodyTooLarge(c, limit)
+					}
+					if details := app.jsonTypeErrors(err, requestType); len(details) > 0 {
+						return errorResponseWithDetails(c, http.StatusBadRequest, "Invalid request body: "+fieldErrorsMessage(details), details)
+					}
+					return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+				}
+				if isFormRequest(c) {
+					if form, err := c.FormParams(); err == nil {
+						if err := bindNestedForm(form, reqPtr); err != nil {
+							return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid form fields: %v", err))
+						}
+					}
+					if err := bindFiles(c, reqPtr, uploads); err != nil {
+						return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid file upload: %v", err))
+					}
 				}
 			}
 
 			// Bind path parameters
-			if err := (&echo.DefaultBinder{}).BindPathParams(c, req); err != nil {
-				return c.JSON(http.StatusBadRequest, Response[any]{
-					Error:   fmt.Sprintf("Invalid path parameters: %v", err),
-					Success: false,
-				})
+			bindPath := func() error { return (&echo.DefaultBinder{}).BindPathParams(c, req) }
+			if err := withoutPathParams(c, sliceParams, bindPath); err != nil {
+				path := url.Values{}
+				values := c.ParamValues()
+				for i, name := range c.ParamNames() {
+					if i < len(values) {
+						path.Add(name, values[i])
+					}
+				}
+				if details := paramTypeErrors(requestType, "param", "path", path); len(details) > 0 {
+					return errorResponseWithDetails(c, http.StatusBadRequest, "Invalid path parameters: "+fieldErrorsMessage(details), details)
+				}
+				return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid path parameters: %v", err))
+			}
+			if err := bindPathSlices(c, reqPtr.Elem(), sliceParams); err != nil {
+				return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid path parameters: %v", err))
 			}
 
-			// Validate request
-			if err := app.validator.Struct(req); err != nil {
-				return c.JSON(http.StatusBadRequest, Response[any]{
-					Error:   fmt.Sprintf("Validation failed: %v", err),
-					Success: false,
-				})
+			// Bind header fields, reporting missing required headers by name
+			if len(headerParams) > 0 {
+				if details := missingHeaders(c, headerParams); len(details) > 0 {
+					return errorResponseWithDetails(c, http.StatusBadRequest, "Missing required headers: "+fieldErrorsMessage(details), details)
+				}
+				if err := (&echo.DefaultBinder{}).BindHeaders(c, req); err != nil {
+					if details := paramTypeErrors(requestType, "header", "header", headerValues(c, headerParams)); len(details) > 0 {
+						return errorResponseWithDetails(c, http.StatusBadRequest, "Invalid headers: "+fieldErrorsMessage(details), details)
+					}
+					return errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid headers: %v", err))
+				}
+			}
+
+			// Fill auto timestamps left empty by the client
+			if len(stamped) > 0 {
+				stampAutoFields(reqPtr.Elem(), stamped, c.Request().Method, time.Now())
 			}
 
-			args = append(args, reqPtr.Elem())
+			// Validate request
+			if !skipValidation {
+				if err := app.validator.Struct(req); err != nil {
+					return errorResponseWithDetails(c, http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), validationErrors(err, requestType, app.naming))
+				}
+			}
 		}
 
-		// Call handler
-		results := handlerValue.Call(args)
+		// Call handler, resolving the dependencies of AppContext handlers
+		ctx := reflect.ValueOf(c)
+		if adapter.context != nil {
+			deps, err := resolveDeps(c, adapter.context.depsType())
+			if err != nil {
+				return handlerErrorResponse(c, err)
+			}
+			ctx = reflect.ValueOf(adapter.context.build(c, deps))
+		}
+		result, returnedStatus, err := adapter.call(ctx, reqPtr)
+		if app.requestLogger != nil {
+			// Runs once the response is written, before the request is released
+			defer app.logRequest(c, start, reqPtr, result, events || download, err)
+		}
+		if err != nil {
+			return handlerErrorResponse(c, err)
+		}
 
 		// Handle response
-		if len(results) > 0 {
-			// Check if last result is an error
-			if len(results) > 1 {
-				if err, ok := results[len(results)-1].Interface().(error); ok && err != nil {
-					// Handle echo.HTTPError specially
-					if he, ok := err.(*echo.HTTPError); ok {
-						return c.JSON(he.Code, Response[any]{
-							Error:   fmt.Sprintf("%v", he.Message),
-							Success: false,
-						})
-					}
-					return c.JSON(http.StatusInternalServerError, Response[any]{
-						Error:   err.Error(),
-						Success: false,
-					})
+		if events {
+			return streamEvents(c, result)
+		}
+		if responseType != nil {
+			// Send redirects without an envelope
+			if redirect, ok := result.Interface().(Redirect); ok && redirect.URL != "" {
+				status := redirect.statusCode()
+				if status < 300 || status > 399 {
+					return errorResponse(c, http.StatusInternalServerError, fmt.Sprintf("Invalid redirect status %d: must be 3xx", status))
 				}
+				return c.Redirect(status, redirect.URL)
+			}
+
+			// Handlers may choose the status; zero falls back to the route's
+			statusCode := successStatus(routeConfig, responseType)
+			if returnedStatus != 0 {
+				statusCode = returnedStatus
+			}
+
+			// Stream files without an envelope
+			if download {
+				return sendFile(c, statusCode, result.Interface().(FileResponse))
 			}
 
 			// Return successful response
-			if results[0].IsValid() && !results[0].IsZero() {
-				// Determine status code
-				statusCode := http.StatusOK
-				if routeConfig != nil && routeConfig.SuccessStatus > 0 {
-					statusCode = routeConfig.SuccessStatus
+			if result.IsValid() && !result.IsZero() {
+				// Answer conditional requests for unchanged resources
+				if notModified(c, result.Interface()) {
+					return c.NoContent(http.StatusNotModified)
+				}
+
+				// The rewrites produce JSON value trees, which only encode as JSON
+				data := result.Interface()
+				if format == echo.MIMEApplicationJSON && app.hasIntEnums(responseType) {
+					encoded, err := app.encodeIntEnums(data)
+					if err != nil {
+						return errorResponse(c, http.StatusInternalServerError, err.Error())
+					}
+					data = encoded
+				}
+				if format == echo.MIMEApplicationJSON && scoped {
+					filtered, err := app.filterScopedFields(c, responseType, data)
+					if err != nil {
+						return errorResponse(c, http.StatusInternalServerError, err.Error())
+					}
+					data = filtered
+				}
+				if format == echo.MIMEApplicationJSON && app.naming != NamingGoField {
+					named, err := app.encodeNames(responseType, data)
+					if err != nil {
+						return errorResponse(c, http.StatusInternalServerError, err.Error())
+					}
+					data = named
 				}
 
-				return c.JSON(statusCode, Response[any]{
-					Data:    results[0].Interface(),
-					Success: true,
-				})
+				envelope := envelopeFor(c)
+				if _, standard := envelope.(StandardEnvelope); standard && app.streamingJSON && format == echo.MIMEApplicationJSON {
+					return streamJSON(c, statusCode, data)
+				}
+				return respond(c, statusCode, envelope.Success(c, data))
+			}
+			if returnedStatus != 0 {
+				return c.NoContent(statusCode)
 			}
+		} else if routeConfig != nil && routeConfig.SuccessStatus > 0 {
+			// Handlers without data may declare another bodiless status, such as 202
+			return c.NoContent(routeConfig.SuccessStatus)
 		}
 
 		return c.NoContent(http.StatusNoContent)
@@ -349,56 +733,96 @@ func (app *App) createEchoHandler(handler interface{}, requestType, responseType
 }
 
 // GenerateOpenAPISpec generates OpenAPI specification from registered routes
+// and returns the app's document. Use MarshalOpenAPISpec to read the spec
+// while other goroutines may be generating it.
 func (app *App) GenerateOpenAPISpec() *openapi3.T {
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	return app.generateSpec()
+}
+
+// generateSpec adds the documented routes to the spec; callers hold specMu
+func (app *App) generateSpec() *openapi3.T {
+	var documented []RouteInfo
 	for _, route := range app.routes {
-		app.addRouteToSpec(route)
+		if route.isEnabled() || app.documentDisabled {
+			documented = append(documented, route)
+		}
+	}
+	app.warnUndeclaredTags(documented)
+	operationIDs := app.operationIDs(documented)
+	for i, route := range documented {
+		app.addRouteToSpec(route, operationIDs[i])
+	}
+	if app.documentCORS {
+		app.addCORSOperations()
+	}
+	for _, process := range app.specProcessors {
+		process(app.spec)
 	}
 	return app.spec
 }
 
 // addRouteToSpec adds a route to the OpenAPI specification
-func (app *App) addRouteToSpec(route RouteInfo) {
-	path := route.Path
-	// Convert Echo path params to OpenAPI format
-	parts := strings.Split(path, "/")
-	for i, part := range parts {
-		if strings.HasPrefix(part, ":") {
-			parts[i] = "{" + part[1:] + "}"
-		}
-	}
-	path = strings.Join(parts, "/")
+func (app *App) addRouteToSpec(route RouteInfo, operationID string) {
+	path := openAPIPath(route.Path)
 
 	if app.spec.Paths[path] == nil {
 		app.spec.Paths[path] = &openapi3.PathItem{}
 	}
 
+	// Fall back to the handler's doc comment for undocumented routes
+	summary, description := route.Summary, route.Description
+	if doc := app.docComments[handlerName(route.Handler)]; doc != "" {
+		if summary == "" {
+			summary = docSummary(doc)
+		}
+		if description == "" {
+			description = doc
+		}
+	}
+
 	operation := &openapi3.Operation{
-		Summary:     route.Summary,
-		Description: route.Description,
-		Tags:        route.Tags,
+		OperationID: operationID,
+		Summary:     summary,
+		Description: description,
+		Tags:        app.routeTags(route),
 		Responses:   openapi3.Responses{},
 		Parameters:  openapi3.Parameters{},
-		Security:    &openapi3.SecurityRequirements{},
 	}
 
-	// Add security requirements if specified
-	if route.RouteConfig != nil && len(route.RouteConfig.Security) > 0 {
-		for _, sec := range route.RouteConfig.Security {
-			secReq := openapi3.SecurityRequirement{}
-			switch sec.Type {
-			case "bearer":
-				secReq["bearerAuth"] = []string{}
-			case "apiKey":
-				if sec.Name != "" {
-					secReq[sec.Name] = []string{}
-				}
-			case "basic":
-				secReq["basicAuth"] = []string{}
-			}
-			*operation.Security = append(*operation.Security, secReq)
+	// Deprecated routes still work; the docs and generated clients warn about them
+	if route.RouteConfig.isDeprecated() {
+		operation.Deprecated = true
+		operation.Description = deprecationNote(operation.Description, route.RouteConfig)
+	}
+
+	// Mark routes that are documented but not served
+	if !route.isEnabled() {
+		setExtension(&operation.Extensions, "x-disabled", true)
+	}
+
+	// Document feature flags that produce response variants
+	if route.RouteConfig != nil && len(route.RouteConfig.Features) > 0 {
+		setExtension(&operation.Extensions, "x-feature-flags", route.RouteConfig.Features)
+	}
+
+	// Copy vendor extensions, which may override those set above
+	if route.RouteConfig != nil {
+		for key, value := range route.RouteConfig.Extensions {
+			setExtension(&operation.Extensions, key, value)
 		}
 	}
 
+	// Add security requirements if specified; public routes clear the
+	// global requirement with an empty list, others inherit it
+	if route.RouteConfig != nil && route.RouteConfig.Public {
+		operation.Security = &openapi3.SecurityRequirements{}
+	} else if route.RouteConfig != nil && len(route.RouteConfig.Security) > 0 {
+		requirements := securityRequirements(route.RouteConfig.Security)
+		operation.Security = &requirements
+	}
+
 	// Extract path parameters
 	pathParts := strings.Split(route.Path, "/")
 	for _, part := range pathParts {
@@ -408,10 +832,8 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 				Name:     paramName,
 				In:       "path",
 				Required: true,
-				Schema: &openapi3.SchemaRef{
-					Value: &openapi3.Schema{Type: "string"},
-				},
 			}
+			app.pathParamSchema(param, route.RequestType)
 			operation.Parameters = append(operation.Parameters, &openapi3.ParameterRef{Value: param})
 		}
 	}
@@ -436,6 +858,15 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 		}
 	}
 
+	// Document headers bound from request struct fields
+	if route.RequestType != nil {
+		var declared map[string]HeaderInfo
+		if route.RouteConfig != nil {
+			declared = route.RouteConfig.RequestHeaders
+		}
+		app.addHeaderParameters(operation, route.RequestType, declared)
+	}
+
 	// Add request body schema if applicable
 	if route.RequestType != nil {
 		if route.Method == "GET" || route.Method == "DELETE" {
@@ -443,20 +874,35 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 			app.addQueryParameters(operation, route.RequestType)
 		} else {
 			// Add request body for POST/PUT/PATCH
-			schema := app.generateSchema(route.RequestType)
+			schema := app.schemaRef(route.RequestType)
 
 			// Determine content types
 			contentTypes := []string{"application/json"}
+			if len(fileFields(route.RequestType)) > 0 {
+				contentTypes = []string{echo.MIMEMultipartForm}
+			} else if hasFormTags(route.RequestType) {
+				// Form-tagged types are bound from form posts as well as JSON
+				contentTypes = append(contentTypes, echo.MIMEApplicationForm)
+			}
 			if route.RouteConfig != nil && len(route.RouteConfig.ContentTypes) > 0 {
 				contentTypes = route.RouteConfig.ContentTypes
 			}
+			if route.RouteConfig != nil && len(route.RouteConfig.ContentSchemas) > 0 {
+				contentTypes = contentTypes[:0:0]
+				for contentType := range route.RouteConfig.ContentSchemas {
+					contentTypes = append(contentTypes, contentType)
+				}
+			}
 
 			content := openapi3.Content{}
 			for _, contentType := range contentTypes {
 				mediaType := &openapi3.MediaType{
-					Schema: &openapi3.SchemaRef{
-						Value: schema,
-					},
+					Schema: schema,
+				}
+				if route.RouteConfig != nil && len(route.RouteConfig.ContentSchemas) > 0 {
+					mediaType.Schema = app.contentSchemaRef(reflect.TypeOf(route.RouteConfig.ContentSchemas[contentType]))
+				} else if contentType == echo.MIMEApplicationForm || contentType == echo.MIMEMultipartForm {
+					mediaType.Schema = app.formSchemaRef(route.RequestType)
 				}
 
 				// Add examples if provided
@@ -476,88 +922,128 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 
 			requestBody := &openapi3.RequestBody{
 				Content:  content,
-				Required: true,
+				Required: route.RouteConfig == nil || !route.RouteConfig.OptionalBody,
 			}
 			operation.RequestBody = &openapi3.RequestBodyRef{Value: requestBody}
 		}
 	}
 
 	// Add response schema
-	if route.ResponseType != nil {
-		schema := app.generateSchema(route.ResponseType)
-		responseSchema := &openapi3.Schema{
-			Type: "object",
-			Properties: openapi3.Schemas{
-				"success": &openapi3.SchemaRef{
-					Value: &openapi3.Schema{Type: "boolean"},
-				},
-				"data": &openapi3.SchemaRef{
-					Value: schema,
-				},
-				"error": &openapi3.SchemaRef{
-					Value: &openapi3.Schema{Type: "string"},
-				},
-			},
-		}
+	if route.ResponseType == redirectType {
+		status, response := redirectResponse(route)
+		operation.Responses[status] = &openapi3.ResponseRef{Value: response}
+	} else if route.ResponseType == fileResponseType {
+		status := strconv.Itoa(successStatus(route.RouteConfig, route.ResponseType))
+		operation.Responses[status] = &openapi3.ResponseRef{Value: fileResponse()}
+	} else if isEventStream(route.ResponseType) {
+		operation.Responses["200"] = &openapi3.ResponseRef{Value: app.eventStreamResponse(route.ResponseType)}
+	} else if route.ResponseType != nil {
+		responseSchema := app.envelope.SuccessSchema(app.schemaRef(route.ResponseType))
 
 		// Determine success status code
-		successStatus := "200"
-		if route.RouteConfig != nil && route.RouteConfig.SuccessStatus > 0 {
-			successStatus = fmt.Sprintf("%d", route.RouteConfig.SuccessStatus)
+		status := successStatus(route.RouteConfig, route.ResponseType)
+		description := "Successful response"
+		if status == http.StatusMultiStatus {
+			description = "Multi-status response with per-item results"
+		}
+
+		mediaType := &openapi3.MediaType{
+			Schema: responseSchema,
+		}
+		if example, ok := app.responseExample(route.ResponseType); ok {
+			if app.naming != NamingGoField {
+				if named, err := app.encodeNames(route.ResponseType, example); err == nil {
+					example = named
+				}
+			}
+			mediaType.Example = app.envelopeExample(example)
 		}
 
 		response := &openapi3.Response{
-			Description: strPtr("Successful response"),
+			Description: strPtr(description),
 			Content: openapi3.Content{
-				"application/json": &openapi3.MediaType{
-					Schema: &openapi3.SchemaRef{Value: responseSchema},
-				},
+				"application/json": mediaType,
 			},
 		}
+		for _, contentType := range app.offeredTypes(route.RouteConfig, route.ResponseType)[1:] {
+			response.Content[contentType] = &openapi3.MediaType{Schema: mediaType.Schema}
+		}
 
 		// Add response headers if specified
 		if route.RouteConfig != nil && len(route.RouteConfig.ResponseHeaders) > 0 {
 			response.Headers = make(openapi3.Headers)
 			for headerName, headerInfo := range route.RouteConfig.ResponseHeaders {
-				schemaType := headerInfo.Schema
-				if schemaType == "" {
-					schemaType = "string"
-				}
-				response.Headers[headerName] = &openapi3.HeaderRef{
-					Value: &openapi3.Header{
-						Parameter: openapi3.Parameter{
-							Description: headerInfo.Description,
-							Schema: &openapi3.SchemaRef{
-								Value: &openapi3.Schema{Type: schemaType},
-							},
-						},
-					},
-				}
+				response.Headers[headerName] = headerRef(headerInfo)
 			}
 		}
 
-		operation.Responses[successStatus] = &openapi3.ResponseRef{Value: response}
-	}
+		// Document rate limit headers returned on every response
+		if route.RouteConfig != nil && route.RouteConfig.RateLimit != nil {
+			if response.Headers == nil {
+				response.Headers = make(openapi3.Headers)
+			}
+			for headerName, header := range rateLimitHeaders() {
+				response.Headers[headerName] = header
+			}
+		}
 
-	// Add error responses
-	errorSchema := &openapi3.Schema{
-		Type: "object",
-		Properties: openapi3.Schemas{
-			"success": &openapi3.SchemaRef{
-				Value: &openapi3.Schema{Type: "boolean", Default: false},
-			},
-			"error": &openapi3.SchemaRef{
-				Value: &openapi3.Schema{Type: "string"},
-			},
-		},
+		// Document the headers that warn clients of deprecated routes
+		if route.RouteConfig.isDeprecated() {
+			if response.Headers == nil {
+				response.Headers = make(openapi3.Headers)
+			}
+			for headerName, header := range deprecationHeaders(route.RouteConfig) {
+				response.Headers[headerName] = header
+			}
+		}
+
+		// Document the links between pages of a collection
+		if isPaginated(route.ResponseType) {
+			if response.Headers == nil {
+				response.Headers = make(openapi3.Headers)
+			}
+			for headerName, header := range paginationHeaders() {
+				response.Headers[headerName] = header
+			}
+		}
+
+		operation.Responses[strconv.Itoa(status)] = &openapi3.ResponseRef{Value: response}
+
+		// Document the other statuses a (T, int, error) handler may choose
+		if route.RouteConfig != nil {
+			for _, other := range route.RouteConfig.Statuses {
+				if _, ok := operation.Responses[strconv.Itoa(other)]; ok {
+					continue
+				}
+				alternate := &openapi3.Response{Description: strPtr(http.StatusText(other))}
+				if other != http.StatusNoContent {
+					alternate.Content = response.Content
+					alternate.Headers = response.Headers
+				}
+				operation.Responses[strconv.Itoa(other)] = &openapi3.ResponseRef{Value: alternate}
+			}
+		}
+	} else {
+		// Handlers without a data result respond with 204 No Content unless
+		// the route declares another status
+		status, description := http.StatusNoContent, "No content"
+		if route.RouteConfig != nil && route.RouteConfig.SuccessStatus > 0 {
+			status, description = route.RouteConfig.SuccessStatus, http.StatusText(route.RouteConfig.SuccessStatus)
+		}
+		operation.Responses[strconv.Itoa(status)] = &openapi3.ResponseRef{
+			Value: &openapi3.Response{Description: strPtr(description)},
+		}
 	}
 
+	// Add error responses, sharing one ErrorResponse component
+	errorSchema := app.errorSchemaRef()
+
 	operation.Responses["400"] = &openapi3.ResponseRef{
 		Value: &openapi3.Response{
 			Description: strPtr("Bad request"),
 			Content: openapi3.Content{
 				"application/json": &openapi3.MediaType{
-					Schema: &openapi3.SchemaRef{Value: errorSchema},
+					Schema: errorSchema,
 				},
 			},
 		},
@@ -568,12 +1054,68 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 			Description: strPtr("Internal server error"),
 			Content: openapi3.Content{
 				"application/json": &openapi3.MediaType{
-					Schema: &openapi3.SchemaRef{Value: errorSchema},
+					Schema: errorSchema,
 				},
 			},
 		},
 	}
 
+	if app.enforceSecurity && len(app.routeSecurity(route.RouteConfig)) > 0 {
+		operation.Responses["401"] = &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: strPtr("Unauthorized"),
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Schema: errorSchema,
+					},
+				},
+			},
+		}
+	}
+
+	if len(app.offeredTypes(route.RouteConfig, route.ResponseType)) > 1 {
+		operation.Responses["406"] = &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: strPtr("Not acceptable"),
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Schema: errorSchema,
+					},
+				},
+			},
+		}
+	}
+
+	if app.bodyLimit(route.RouteConfig) > 0 && route.Method != "GET" && route.Method != "DELETE" {
+		operation.Responses["413"] = &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: strPtr("Request body too large"),
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Schema: errorSchema,
+					},
+				},
+			},
+		}
+	}
+
+	if route.RouteConfig != nil && route.RouteConfig.RateLimit != nil {
+		operation.Responses["429"] = &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: strPtr("Too many requests"),
+				Headers:     rateLimitHeaders(),
+				Content: openapi3.Content{
+					"application/json": &openapi3.MediaType{
+						Schema: errorSchema,
+					},
+				},
+			},
+		}
+	}
+
+	// Declared responses override the defaults
+	app.addDeclaredResponses(operation, route.RouteConfig, errorSchema)
+
 	// Set operation on the path
 	switch route.Method {
 	case "GET":
@@ -589,47 +1131,105 @@ func (app *App) addRouteToSpec(route RouteInfo) {
 	}
 }
 
-// addQueryParameters adds query parameters to operation from struct
-func (app *App) addQueryParameters(operation *openapi3.Operation, t reflect.Type) {
+// isEmbeddedStruct reports whether field is an embedded struct without a
+// JSON name, whose fields encoding/json promotes into the parent
+func isEmbeddedStruct(field reflect.StructField) bool {
+	t := field.Type
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
+	if !field.Anonymous || t.Kind() != reflect.Struct || t == timeType || t == fileHeaderType {
+		return false
+	}
+	return strings.Split(field.Tag.Get("json"), ",")[0] == ""
+}
 
-	if t.Kind() != reflect.Struct {
-		return
+// requiredRule reports whether a validate tag requires the field itself
+func requiredRule(validateTag string) bool {
+	for _, rule := range strings.Split(validateTag, ",") {
+		if rule == "dive" {
+			return false
+		}
+		if rule == "required" {
+			return true
+		}
 	}
+	return false
+}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		queryTag := field.Tag.Get("query")
-		if queryTag == "" || queryTag == "-" {
-			continue
+// openAPIPath converts Echo path params such as :id to OpenAPI's {id}
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = "{" + part[1:] + "}"
 		}
+	}
+	return strings.Join(parts, "/")
+}
 
+// addQueryParameters adds query parameters to operation from struct
+func (app *App) addQueryParameters(operation *openapi3.Operation, t reflect.Type) {
+	for _, query := range queryParams(t) {
 		required := false
-		if validateTag := field.Tag.Get("validate"); validateTag != "" {
+		if validateTag := query.field.Tag.Get("validate"); validateTag != "" && !query.inPtr {
 			required = strings.Contains(validateTag, "required")
 		}
 
+		// Structs bound from one value, such as text unmarshalers, are sent as strings
+		schema := &openapi3.Schema{Type: "string"}
+		fieldType := query.field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType == timeType || fieldType.Kind() != reflect.Struct {
+			schema = app.generateSchema(query.field.Type)
+		}
+
+		// Parameters with a default may be omitted
+		if tag, ok := query.field.Tag.Lookup("default"); ok {
+			schema.Default = app.tagExample(query.field.Type, tag)
+			required = false
+		}
+
 		param := &openapi3.Parameter{
-			Name:     queryTag,
+			Name:     query.name,
 			In:       "query",
 			Required: required,
-			Schema: &openapi3.SchemaRef{
-				Value: app.generateSchema(field.Type),
-			},
+			Schema:   &openapi3.SchemaRef{Value: schema},
 		}
 
 		operation.Parameters = append(operation.Parameters, &openapi3.ParameterRef{Value: param})
 	}
 }
 
-// generateSchema generates OpenAPI schema from Go type
-func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
+// buildSchema generates OpenAPI schema from Go type
+func (app *App) buildSchema(t reflect.Type) *openapi3.Schema {
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
 
+	// Registered int enums are documented by name
+	if enum, ok := app.intEnums[t]; ok {
+		enums := make([]interface{}, len(enum.order))
+		for i, name := range enum.order {
+			enums[i] = name
+		}
+		return &openapi3.Schema{Type: "string", Enum: enums}
+	}
+
+	// Named scalar types may list their values
+	if values, ok := app.typeEnum(t); ok {
+		schemaType := "integer"
+		switch t.Kind() {
+		case reflect.String:
+			schemaType = "string"
+		case reflect.Float32, reflect.Float64:
+			schemaType = "number"
+		}
+		return &openapi3.Schema{Type: schemaType, Enum: values}
+	}
+
 	switch t.Kind() {
 	case reflect.String:
 		return &openapi3.Schema{Type: "string"}
@@ -639,16 +1239,29 @@ func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
 		return &openapi3.Schema{Type: "number"}
 	case reflect.Bool:
 		return &openapi3.Schema{Type: "boolean"}
-	case reflect.Slice:
-		return &openapi3.Schema{
+	case reflect.Slice, reflect.Array:
+		// encoding/json sends byte slices as base64 strings, unless they encode
+		// themselves, as json.RawMessage does with any JSON value
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			if reflect.PtrTo(t).Implements(jsonMarshalerType) {
+				return &openapi3.Schema{}
+			}
+			return &openapi3.Schema{Type: "string", Format: "byte"}
+		}
+		schema := &openapi3.Schema{
 			Type:  "array",
-			Items: &openapi3.SchemaRef{Value: app.generateSchema(t.Elem())},
+			Items: app.schemaRef(t.Elem()),
+		}
+		if t.Kind() == reflect.Array {
+			length := uint64(t.Len())
+			schema.MinItems, schema.MaxItems = length, &length
 		}
+		return schema
 	case reflect.Map:
 		return &openapi3.Schema{
 			Type: "object",
 			AdditionalProperties: openapi3.AdditionalProperties{
-				Schema: &openapi3.SchemaRef{Value: app.generateSchema(t.Elem())},
+				Schema: app.schemaRef(t.Elem()),
 			},
 		}
 	case reflect.Struct:
@@ -656,6 +1269,9 @@ func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
 		if t.String() == "time.Time" {
 			return &openapi3.Schema{Type: "string", Format: "date-time"}
 		}
+		if t == fileHeaderType {
+			return &openapi3.Schema{Type: "string", Format: "binary"}
+		}
 
 		schema := &openapi3.Schema{
 			Type:       "object",
@@ -663,70 +1279,110 @@ func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
 			Required:   []string{},
 		}
 
+		// Fields declared here shadow those promoted from embedded structs
+		declared := map[string]bool{}
+		for i := 0; i < t.NumField(); i++ {
+			if name, ok := app.naming.fieldName(t.Field(i)); ok && !isEmbeddedStruct(t.Field(i)) {
+				declared[name] = true
+			}
+		}
+
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
 			jsonTag := field.Tag.Get("json")
 			if jsonTag == "-" {
 				continue
 			}
+			// Header fields are documented as header parameters
+			if headerTag := field.Tag.Get("header"); headerTag != "" && headerTag != "-" {
+				continue
+			}
+
+			// Embedded structs are flattened like encoding/json does, keeping
+			// their own required fields. Those of embedded pointers may be absent.
+			if isEmbeddedStruct(field) {
+				embedded := app.generateSchema(field.Type)
+				required := map[string]bool{}
+				if field.Type.Kind() != reflect.Ptr {
+					for _, name := range embedded.Required {
+						required[name] = true
+					}
+				}
+				names := make([]string, 0, len(embedded.Properties))
+				for name := range embedded.Properties {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					if _, taken := schema.Properties[name]; taken || declared[name] {
+						continue
+					}
+					schema.Properties[name] = embedded.Properties[name]
+					if required[name] {
+						schema.Required = append(schema.Required, name)
+					}
+				}
+				continue
+			}
 
-			fieldName := field.Name
+			fieldName, _ := app.naming.fieldName(field)
 			omitempty := false
 			if jsonTag != "" {
 				parts := strings.Split(jsonTag, ",")
-				fieldName = parts[0]
 				for _, part := range parts[1:] {
-					if part == "omitempty" {
+					// encoding/json never omits struct values
+					if part == "omitempty" && field.Type.Kind() != reflect.Struct {
 						omitempty = true
 					}
 				}
 			}
 
-			fieldSchema := app.generateSchema(field.Type)
+			fieldRef := app.schemaRef(field.Type)
+			fieldSchema := fieldRef.Value
+			if fieldRef.Ref != "" {
+				// Keywords beside a $ref are ignored, so decorate a wrapper instead of the shared component
+				fieldSchema = &openapi3.Schema{}
+			}
 
-			// Add example from struct tag
+			// Add example from struct tag, typed like the field
 			if exampleTag := field.Tag.Get("example"); exampleTag != "" {
-				fieldSchema.Example = exampleTag
+				fieldSchema.Example = app.tagExample(field.Type, exampleTag)
+			}
+
+			// Auto timestamps are set by the server
+			if auto := field.Tag.Get("auto"); auto == "createOnBind" || auto == "updateOnBind" {
+				fieldSchema.ReadOnly = true
+			}
+
+			// Note fields only returned to callers holding a scope
+			if scope := field.Tag.Get("scope"); scope != "" {
+				setExtension(&fieldSchema.Extensions, "x-required-scope", scope)
 			}
 
 			// Add validation from struct tags
 			if validateTag := field.Tag.Get("validate"); validateTag != "" {
-				if strings.Contains(validateTag, "required") && !omitempty {
+				// Only the field's own required rule counts; conditional rules
+				// such as required_if and rules after dive do not
+				if requiredRule(validateTag) && !omitempty {
 					schema.Required = append(schema.Required, fieldName)
 				}
 
 				// Parse additional validations
 				validations := strings.Split(validateTag, ",")
 				for _, v := range validations {
-					if strings.HasPrefix(v, "min=") {
-						if val := strings.TrimPrefix(v, "min="); val != "" {
-							if fieldSchema.Type == "string" {
-								if minLen, err := strconv.Atoi(val); err == nil {
-									fieldSchema.MinLength = uint64(minLen)
-								}
-							} else if fieldSchema.Type == "integer" || fieldSchema.Type == "number" {
-								if min, err := strconv.ParseFloat(val, 64); err == nil {
-									fieldSchema.Min = &min
-								}
-							}
-						}
+					if v == "dive" {
+						// Remaining rules apply to the elements, not the list
+						break
 					}
-					if strings.HasPrefix(v, "max=") {
-						if val := strings.TrimPrefix(v, "max="); val != "" {
-							if fieldSchema.Type == "string" {
-								if maxLen, err := strconv.Atoi(val); err == nil {
-									maxLenValue := uint64(maxLen)
-									fieldSchema.MaxLength = &maxLenValue
-								}
-							} else if fieldSchema.Type == "integer" || fieldSchema.Type == "number" {
-								if max, err := strconv.ParseFloat(val, 64); err == nil {
-									fieldSchema.Max = &max
-								}
-							}
-						}
+					if rule, value, ok := strings.Cut(v, "="); ok && boundRules[rule] {
+						app.applyBound(fieldSchema, fieldName, rule, value)
 					}
-					if v == "email" {
-						fieldSchema.Format = "email"
+					if format, ok := formatRules[v]; ok {
+						fieldSchema.Format = format
+					}
+					applyPattern(fieldSchema, v)
+					if layout, ok := strings.CutPrefix(v, "datetime="); ok {
+						applyDatetime(fieldSchema, layout)
 					}
 					if strings.HasPrefix(v, "oneof=") {
 						values := strings.Split(strings.TrimPrefix(v, "oneof="), " ")
@@ -736,10 +1392,37 @@ func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
 						}
 						fieldSchema.Enum = enums
 					}
+
+					// Document custom validation tags
+					if apply, ok := app.tagSchemas[strings.SplitN(v, "=", 2)[0]]; ok {
+						apply(fieldSchema)
+					}
 				}
 			}
 
-			schema.Properties[fieldName] = &openapi3.SchemaRef{Value: fieldSchema}
+			// A pattern tag documents formats the validate rules can't express
+			if pattern := field.Tag.Get("pattern"); pattern != "" {
+				fieldSchema.Pattern = pattern
+			}
+
+			// Pointers may be null unless validation requires a value
+			if field.Type.Kind() == reflect.Ptr && !isFileType(field.Type) && !hasValidateTag(field, "required") {
+				fieldSchema.Nullable = true
+				if len(fieldSchema.Enum) > 0 {
+					fieldSchema.Enum = append(fieldSchema.Enum, nil)
+				}
+			}
+
+			// Prefill untagged scalars so "Try it out" starts from valid values
+			if fieldRef.Ref == "" && fieldSchema.Example == nil {
+				fieldSchema.Example = typeExample(fieldSchema)
+			}
+
+			if fieldRef.Ref != "" && !reflect.DeepEqual(fieldSchema, &openapi3.Schema{}) {
+				fieldSchema.AllOf = openapi3.SchemaRefs{fieldRef}
+				fieldRef = &openapi3.SchemaRef{Value: fieldSchema}
+			}
+			schema.Properties[fieldName] = fieldRef
 		}
 
 		return schema
@@ -748,47 +1431,106 @@ func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
 	}
 }
 
-// ServeOpenAPISpec serves the OpenAPI specification
+// ServeOpenAPISpec serves the OpenAPI specification as JSON, or as YAML when
+// requested with Accept: application/yaml or ?format=yaml, or when path ends
+// in .yaml or .yml
 func (app *App) ServeOpenAPISpec(path string) {
 	app.Echo.GET(path, func(c echo.Context) error {
-		return c.JSON(http.StatusOK, app.GenerateOpenAPISpec())
+		return app.writeSpec(c, wantsYAML(c))
 	})
 }
 
 // ServeSwaggerUI serves Swagger UI for API documentation
 func (app *App) ServeSwaggerUI(path string, specPath string) {
-	app.Echo.GET(path, func(c echo.Context) error {
-		html := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <title>%s - API Documentation</title>
-    <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
-</head>
-<body>
-    <div id="swagger-ui"></div>
-    <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
-    <script>
-        window.onload = function() {
-            SwaggerUIBundle({
-                url: "%s",
-                dom_id: '#swagger-ui',
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIBundle.presets.standalone
-                ],
-                layout: "BaseLayout",
-                deepLinking: true
-            });
-        }
-    </script>
-</body>
-</html>`, app.spec.Info.Title, specPath)
-		return c.HTML(http.StatusOK, html)
-	})
+	app.ServeSwaggerUIWithConfig(path, specPath, SwaggerUIConfig{})
 }
 
+// errorType is the reflected error interface
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+var contextType = reflect.TypeOf((*echo.Context)(nil)).Elem()
+
 // Helper functions
 func strPtr(s string) *string {
 	return &s
 }
+
+// setExtension sets an OpenAPI extension, allocating the map if needed
+func setExtension(extensions *map[string]interface{}, key string, value interface{}) {
+	if *extensions == nil {
+		*extensions = map[string]interface{}{}
+	}
+	(*extensions)[key] = value
+}
+
+// requestBodyEmpty reports whether the request has no body, peeking at
+// bodies of unknown length without consuming them
+func requestBodyEmpty(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+		return true
+	}
+	if req.ContentLength > 0 {
+		return false
+	}
+
+	var first [1]byte
+	n, _ := io.ReadFull(req.Body, first[:])
+	if n == 0 {
+		return true
+	}
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(first[:n]), req.Body), req.Body}
+	return false
+}
+
+// handlerErrorResponse writes the error envelope for an error returned by a
+// handler or a dependency provider
+func handlerErrorResponse(c echo.Context, err error) error {
+	return errorResponseFor(c, err, http.StatusInternalServerError)
+}
+
+// errorResponseFor writes the error envelope for err, sent with fallback
+// when neither the error mappers nor err itself give a status
+func errorResponseFor(c echo.Context, err error, fallback int) error {
+	if status, message, ok := mapError(c, err); ok {
+		return errorResponse(c, status, message)
+	}
+	// Handle echo.HTTPError specially
+	if he, ok := err.(*echo.HTTPError); ok {
+		return errorResponse(c, he.Code, fmt.Sprintf("%v", he.Message))
+	}
+	// Domain errors may carry their own status
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		return errorResponse(c, coder.StatusCode(), err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) && c.Request().Context().Err() != nil {
+		return errorResponse(c, http.StatusServiceUnavailable, "Request timed out")
+	}
+	return errorResponse(c, fallback, err.Error())
+}
+
+// headerRef documents a response header
+func headerRef(info HeaderInfo) *openapi3.HeaderRef {
+	schemaType := info.Schema
+	if schemaType == "" {
+		schemaType = "string"
+	}
+	return &openapi3.HeaderRef{
+		Value: &openapi3.Header{
+			Parameter: openapi3.Parameter{
+				Description: info.Description,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{Type: schemaType},
+				},
+			},
+		},
+	}
+}
+
+// errorResponse writes an error envelope, tagged with the request's correlation ID
+func errorResponse(c echo.Context, status int, message string) error {
+	return errorResponseWithDetails(c, status, message, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -151,6 +152,85 @@ func TestOpenAPIGeneration(t *testing.T) {
 	assert.NotNil(t, spec.Paths["/users"].Post.RequestBody)
 }
 
+func TestPointerFieldsNullable(t *testing.T) {
+	type PatchTodoRequest struct {
+		Title     *string `json:"title,omitempty" validate:"omitempty,min=3"`
+		Completed *bool   `json:"completed,omitempty"`
+		Owner     *Author `json:"owner,omitempty"`
+		Priority  *int    `json:"priority" validate:"required"`
+		Notes     string  `json:"notes"`
+	}
+
+	app := echonext.New()
+	app.PATCH("/todos/:id", func(c echo.Context, req PatchTodoRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	schema := spec.Paths["/todos/{id}"].Patch.RequestBody.Value.Content["application/json"].Schema.Value
+	properties := schema.Properties
+
+	assert.True(t, properties["title"].Value.Nullable)
+	assert.Equal(t, uint64(3), properties["title"].Value.MinLength)
+	assert.True(t, properties["completed"].Value.Nullable)
+	assert.Equal(t, "boolean", properties["completed"].Value.Type)
+	assert.False(t, properties["notes"].Value.Nullable)
+
+	// Pointer to struct keeps its schema behind a nullable wrapper
+	owner := properties["owner"].Value
+	assert.True(t, owner.Nullable)
+	if assert.Len(t, owner.AllOf, 1) {
+		assert.Equal(t, "#/components/schemas/Author", owner.AllOf[0].Ref)
+		assert.Contains(t, owner.AllOf[0].Value.Properties, "name")
+	}
+
+	// Required pointers must be present and non-null
+	assert.False(t, properties["priority"].Value.Nullable)
+	assert.Equal(t, []string{"priority"}, schema.Required)
+}
+
+func TestNestedRequiredFields(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+		City   string `json:"city" validate:"required"`
+		Zip    string `json:"zip,omitempty"`
+	}
+	type Audit struct {
+		Source string `json:"source" validate:"required"`
+		Note   string `json:"note" validate:"required"`
+	}
+	type Tracking struct {
+		Campaign string `json:"campaign" validate:"required"`
+	}
+	type SignupRequest struct {
+		Audit
+		*Tracking
+		Name     string    `json:"name" validate:"required"`
+		Note     string    `json:"note"`
+		Address  Address   `json:"address,omitempty" validate:"required"`
+		Previous *Address  `json:"previous,omitempty"`
+		Others   []Address `json:"others" validate:"dive,required"`
+		Referrer string    `json:"referrer" validate:"required_with=Campaign"`
+	}
+
+	app := echonext.New()
+	app.POST("/signups", func(c echo.Context, req SignupRequest) (TestUser, error) { return TestUser{}, nil })
+
+	schemas := app.GenerateOpenAPISpec().Components.Schemas
+	signup := schemas["SignupRequest"].Value
+
+	// Struct values are never omitted, so omitempty doesn't make address optional
+	assert.Equal(t, []string{"source", "name", "address"}, signup.Required)
+	assert.Len(t, signup.Properties, 8)
+	assert.Contains(t, signup.Properties, "source")
+	assert.Contains(t, signup.Properties, "campaign")
+	assert.Equal(t, "string", signup.Properties["note"].Value.Type, "declared fields shadow promoted ones")
+	assert.NotContains(t, schemas, "Audit", "embedded structs are flattened")
+
+	// Nested structs keep their own required fields
+	assert.Equal(t, []string{"street", "city"}, schemas["Address"].Value.Required)
+}
+
 func TestQueryParameters(t *testing.T) {
 	app := echonext.New()
 
@@ -434,3 +514,199 @@ func TestCustomStatusCodes(t *testing.T) {
 	// Should return 201 Created instead of 200 OK
 	assert.Equal(t, 201, rec.Code)
 }
+
+func TestHandlerReturnedStatus(t *testing.T) {
+	app := echonext.New()
+	existing := map[string]bool{"1": true}
+
+	app.PUT("/users/:id", func(c echo.Context, req TestUser) (TestUser, int, error) {
+		req.ID = c.Param("id")
+		if existing[req.ID] {
+			return req, 0, nil
+		}
+		existing[req.ID] = true
+		return req, http.StatusCreated, nil
+	}, echonext.Route{Statuses: []int{http.StatusCreated}})
+	app.DELETE("/jobs/:id", func(c echo.Context) (TestUser, int, error) {
+		return TestUser{}, http.StatusAccepted, nil
+	})
+
+	put := func(id string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(TestUser{Name: "John"})
+		req := httptest.NewRequest(http.MethodPut, "/users/"+id, bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusCreated, put("2").Code)
+	assert.Equal(t, http.StatusOK, put("2").Code, "zero status falls back to the route's")
+
+	req := httptest.NewRequest(http.MethodDelete, "/jobs/1", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	responses := app.GenerateOpenAPISpec().Paths["/users/{id}"].Put.Responses
+	assert.Contains(t, responses, "200")
+	assert.Equal(t, responses["200"].Value.Content, responses["201"].Value.Content)
+
+	assert.PanicsWithValue(t, "handler returning three values must return (T, int, error)", func() {
+		app.GET("/bad", func(c echo.Context) (TestUser, string, error) { return TestUser{}, "", nil })
+	})
+}
+
+func TestRequestsDoNotLeakBetweenCalls(t *testing.T) {
+	type UpdateRequest struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	app := echonext.New()
+	var seen []UpdateRequest
+	app.PATCH("/users/:id", func(c echo.Context, req UpdateRequest) (TestUser, error) {
+		seen = append(seen, req)
+		return TestUser{ID: c.Param("id")}, nil
+	})
+
+	for _, body := range []string{`{"name":"Ada","tags":["admin"]}`, `{}`} {
+		req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []UpdateRequest{{Name: "Ada", Tags: []string{"admin"}}, {}}, seen)
+}
+
+func TestOptionalBody(t *testing.T) {
+	app := echonext.New()
+
+	type UpdateSettingsRequest struct {
+		Theme string `json:"theme" validate:"required"`
+	}
+
+	app.POST("/settings/:id/reset", func(c echo.Context, req UpdateSettingsRequest) (map[string]string, error) {
+		theme := req.Theme
+		if theme == "" {
+			theme = "default"
+		}
+		return map[string]string{"theme": theme}, nil
+	}, echonext.Route{
+		OptionalBody: true,
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/settings/1/reset", nil)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response echonext.Response[map[string]string]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "default", response.Data["theme"])
+	})
+
+	t.Run("body still validated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/settings/1/reset", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("documented as optional", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		assert.False(t, spec.Paths["/settings/{id}/reset"].Post.RequestBody.Value.Required)
+	})
+}
+
+func TestNoContentHandlers(t *testing.T) {
+	app := echonext.New()
+
+	app.DELETE("/users/:id", func(c echo.Context) error {
+		if c.Param("id") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		}
+		return nil
+	})
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/users/missing", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "user not found", response.Error)
+	})
+
+	t.Run("documented as 204", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		responses := spec.Paths["/users/{id}"].Delete.Responses
+		assert.Contains(t, responses, "204")
+		assert.NotContains(t, responses, "200")
+		assert.Nil(t, responses["204"].Value.Content)
+	})
+
+	t.Run("declared status", func(t *testing.T) {
+		app := echonext.New()
+		app.POST("/exports", func(c echo.Context) error { return nil }, echonext.Route{SuccessStatus: http.StatusAccepted})
+
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/exports", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Body.String())
+
+		responses := app.GenerateOpenAPISpec().Paths["/exports"].Post.Responses
+		assert.Contains(t, responses, "202")
+		assert.NotContains(t, responses, "204")
+		assert.Nil(t, responses["202"].Value.Content)
+	})
+}
+
+// BenchmarkTypedHandler measures the per-request overhead of typed handlers,
+// calling the Echo handler directly so routing and recording stay out of it
+func BenchmarkTypedHandler(b *testing.B) {
+	app := echonext.New()
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		return TestUser{ID: c.Param("id")}, nil
+	})
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{Name: req.Name, Email: req.Email}, nil
+	})
+	body, _ := json.Marshal(CreateUserRequest{Name: "John Doe", Email: "john@example.com"})
+
+	run := func(b *testing.B, method, path string, body []byte) {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		reader := bytes.NewReader(body)
+		rec := httptest.NewRecorder()
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			reader.Reset(body)
+			req.Body = io.NopCloser(reader)
+			rec.Body.Reset()
+			app.ServeHTTP(rec, req)
+		}
+	}
+
+	b.Run("without request", func(b *testing.B) { run(b, http.MethodGet, "/users/1", nil) })
+	b.Run("with request", func(b *testing.B) { run(b, http.MethodPost, "/users", body) })
+}
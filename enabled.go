@@ -0,0 +1,13 @@
+package echonext
+
+// SetDocumentDisabledRoutes includes routes registered with Route.Enabled set
+// to false in the spec, marked with x-disabled, e.g. for a beta spec
+func (app *App) SetDocumentDisabledRoutes(enabled bool) {
+	app.documentDisabled = enabled
+}
+
+// isEnabled reports whether a route is served. Routes are enabled unless
+// Route.Enabled is set to false.
+func (route RouteInfo) isEnabled() bool {
+	return route.RouteConfig == nil || route.RouteConfig.Enabled == nil || *route.RouteConfig.Enabled
+}
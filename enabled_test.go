@@ -0,0 +1,43 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabledRoutes(t *testing.T) {
+	betaEnabled := false
+	handler := func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil }
+
+	app := echonext.New()
+	app.GET("/users", handler)
+	app.GET("/recommendations", handler, echonext.Route{Enabled: &betaEnabled})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/recommendations", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	t.Run("hidden from spec by default", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		assert.NotContains(t, spec.Paths, "/recommendations")
+		assert.Contains(t, spec.Paths, "/users")
+	})
+
+	t.Run("beta spec", func(t *testing.T) {
+		beta := echonext.New()
+		beta.SetDocumentDisabledRoutes(true)
+		beta.GET("/recommendations", handler, echonext.Route{Enabled: &betaEnabled})
+
+		operation := beta.GenerateOpenAPISpec().Paths["/recommendations"].Get
+		assert.Equal(t, true, operation.Extensions["x-disabled"])
+	})
+}
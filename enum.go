@@ -0,0 +1,357 @@
+package echonext
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// intEnum maps the values of an integer enum type to their names
+type intEnum struct {
+	names  map[int64]string
+	values map[string]int64
+	order  []string // Names sorted by value
+}
+
+// RegisterIntEnum makes fields of an integer enum type serialize, bind and
+// document as their string names. zero is any value of the enum type and
+// names maps each enum value to its name:
+//
+//	app.RegisterIntEnum(Priority(0), map[Priority]string{Low: "low", High: "high"})
+func (app *App) RegisterIntEnum(zero interface{}, names interface{}) {
+	enumType := reflect.TypeOf(zero)
+	if enumType == nil || !isIntKind(enumType.Kind()) {
+		panic("enum type must be an integer type")
+	}
+
+	namesValue := reflect.ValueOf(names)
+	if namesValue.Kind() != reflect.Map || namesValue.Type().Key() != enumType || namesValue.Type().Elem().Kind() != reflect.String {
+		panic(fmt.Sprintf("enum names must be a map[%s]string", enumType))
+	}
+
+	enum := intEnum{
+		names:  make(map[int64]string, namesValue.Len()),
+		values: make(map[string]int64, namesValue.Len()),
+	}
+	values := make([]int64, 0, namesValue.Len())
+	iter := namesValue.MapRange()
+	for iter.Next() {
+		value := iter.Key().Int()
+		name := iter.Value().String()
+		enum.names[value] = name
+		enum.values[name] = value
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	for _, value := range values {
+		enum.order = append(enum.order, enum.names[value])
+	}
+
+	if app.intEnums == nil {
+		app.intEnums = make(map[reflect.Type]intEnum)
+	}
+	app.intEnums[enumType] = enum
+	app.invalidateSchemas()
+}
+
+// Enum is implemented by named string and number types with a fixed set of
+// values, such as a type Status string with constants. Fields of the type are
+// documented with those values as an enum.
+type Enum interface {
+	Values() []interface{}
+}
+
+var enumInterface = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// AddEnum documents fields of t with values as an enum, for types from other
+// packages that don't implement Enum. It takes precedence over a Values method.
+func (app *App) AddEnum(t reflect.Type, values []interface{}) {
+	if t == nil || !isScalarKind(t.Kind()) {
+		panic("echonext: enum type must be a string, integer or float type")
+	}
+	if len(values) == 0 {
+		panic(fmt.Sprintf("echonext: enum %s has no values", t))
+	}
+	if app.enums == nil {
+		app.enums = make(map[reflect.Type][]interface{})
+	}
+	app.enums[t] = enumValues(t, values)
+	app.invalidateSchemas()
+}
+
+// typeEnum returns the enum values of t, registered with AddEnum or reported
+// by its Values method
+func (app *App) typeEnum(t reflect.Type) ([]interface{}, bool) {
+	if values, ok := app.enums[t]; ok {
+		return values, true
+	}
+	if t.Name() == "" || !isScalarKind(t.Kind()) {
+		return nil, false
+	}
+	var enum Enum
+	switch {
+	case t.Implements(enumInterface):
+		enum = reflect.Zero(t).Interface().(Enum)
+	case reflect.PointerTo(t).Implements(enumInterface):
+		enum = reflect.New(t).Interface().(Enum)
+	default:
+		return nil, false
+	}
+	values := enum.Values()
+	if len(values) == 0 {
+		return nil, false
+	}
+	return enumValues(t, values), true
+}
+
+// enumValues converts enum values to strings or float64s, the types values
+// decoded from JSON have, so examples and requests compare equal to them
+func enumValues(t reflect.Type, values []interface{}) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i, value := range values {
+		v := reflect.ValueOf(value)
+		if !v.IsValid() || !v.Type().ConvertibleTo(t) {
+			panic(fmt.Sprintf("echonext: enum value %v is not a %s", value, t))
+		}
+		v = v.Convert(t)
+		switch {
+		case t.Kind() == reflect.String:
+			converted[i] = v.String()
+		case isIntKind(t.Kind()):
+			converted[i] = float64(v.Int())
+		case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+			converted[i] = v.Float()
+		default:
+			converted[i] = float64(v.Uint())
+		}
+	}
+	return converted
+}
+
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return isIntKind(k)
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// hasIntEnums reports whether t refers to a registered int enum anywhere in its structure
+func (app *App) hasIntEnums(t reflect.Type) bool {
+	if len(app.intEnums) == 0 || t == nil {
+		return false
+	}
+	return app.containsIntEnum(t, map[reflect.Type]bool{})
+}
+
+func (app *App) containsIntEnum(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := app.intEnums[t]; ok {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return app.containsIntEnum(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if app.containsIntEnum(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// encodeIntEnums converts v into a JSON value tree with int enums replaced by their names
+func (app *App) encodeIntEnums(v interface{}) (interface{}, error) {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return nil, err
+	}
+	return app.walkIntEnums(reflect.TypeOf(v), tree, true), nil
+}
+
+// jsonTree converts v into the generic value tree of its JSON encoding
+func jsonTree(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// decodeIntEnumBody rewrites a JSON request body so enum names become their
+// numeric values. It returns the error reading the body; bodies that fail to
+// parse are left untouched for the binder to report.
+func (app *App) decodeIntEnumBody(c echo.Context, t reflect.Type) error {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil
+	}
+	rewritten, err := json.Marshal(app.walkIntEnums(t, tree, false))
+	if err != nil {
+		return nil
+	}
+	req.Body = io.NopCloser(bytes.NewReader(rewritten))
+	req.ContentLength = int64(len(rewritten))
+	return nil
+}
+
+// decodeIntEnumQuery replaces enum names in query parameters with their numeric values
+func (app *App) decodeIntEnumQuery(c echo.Context, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	query := c.QueryParams()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		queryTag := field.Tag.Get("query")
+		if queryTag == "" || queryTag == "-" {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		enum, ok := app.intEnums[fieldType]
+		if !ok {
+			continue
+		}
+		for j, raw := range query[queryTag] {
+			if value, ok := enum.values[raw]; ok {
+				query[queryTag][j] = strconv.FormatInt(value, 10)
+			}
+		}
+	}
+}
+
+// walkIntEnums walks a decoded JSON tree alongside its Go type, converting
+// enum values to names (toName) or names to values
+func (app *App) walkIntEnums(t reflect.Type, node interface{}, toName bool) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if enum, ok := app.intEnums[t]; ok {
+		if toName {
+			if n, ok := node.(json.Number); ok {
+				if value, err := n.Int64(); err == nil {
+					if name, ok := enum.names[value]; ok {
+						return name
+					}
+				}
+			}
+		} else if s, ok := node.(string); ok {
+			if value, ok := enum.values[s]; ok {
+				return json.Number(strconv.FormatInt(value, 10))
+			}
+		}
+		return node
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := node.([]interface{}); ok {
+			for i, item := range items {
+				items[i] = app.walkIntEnums(t.Elem(), item, toName)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := node.(map[string]interface{}); ok {
+			for k, v := range obj {
+				obj[k] = app.walkIntEnums(t.Elem(), v, toName)
+			}
+		}
+	case reflect.Struct:
+		if obj, ok := node.(map[string]interface{}); ok {
+			app.walkIntEnumFields(t, obj, toName)
+		}
+	}
+	return node
+}
+
+func (app *App) walkIntEnumFields(t reflect.Type, obj map[string]interface{}, toName bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened into the parent
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && fieldType.Kind() == reflect.Struct {
+			app.walkIntEnumFields(fieldType, obj, toName)
+			continue
+		}
+
+		if v, exists := obj[name]; exists {
+			obj[name] = app.walkIntEnums(field.Type, v, toName)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON property name of a struct field and false
+// when encoding/json skips the field
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "-" {
+		return "", false
+	}
+	if name := strings.Split(jsonTag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
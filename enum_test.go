@@ -0,0 +1,200 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityMedium
+	PriorityHigh
+)
+
+type Task struct {
+	Title    string   `json:"title" validate:"required"`
+	Priority Priority `json:"priority"`
+}
+
+type ListTasksRequest struct {
+	Priority Priority `query:"priority"`
+}
+
+func TestIntEnum(t *testing.T) {
+	app := echonext.New()
+	app.RegisterIntEnum(PriorityLow, map[Priority]string{
+		PriorityLow:    "low",
+		PriorityMedium: "medium",
+		PriorityHigh:   "high",
+	})
+
+	var received Task
+	app.POST("/tasks", func(c echo.Context, req Task) (Task, error) {
+		received = req
+		return req, nil
+	})
+
+	app.GET("/tasks", func(c echo.Context, req ListTasksRequest) ([]Task, error) {
+		return []Task{{Title: "Filtered", Priority: req.Priority}}, nil
+	})
+
+	t.Run("round trip by name", func(t *testing.T) {
+		body := []byte(`{"title":"Ship it","priority":"high"}`)
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, PriorityHigh, received.Priority)
+
+		var response echonext.Response[map[string]interface{}]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "high", response.Data["priority"])
+	})
+
+	t.Run("query parameter by name", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks?priority=medium", nil)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response echonext.Response[[]map[string]interface{}]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "medium", response.Data[0]["priority"])
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		app.SetMaxBodyBytes(20)
+		defer app.SetMaxBodyBytes(0)
+
+		// Bodies without a Content-Length are cut off while reading
+		body := io.MultiReader(strings.NewReader(`{"title":"Ship it","priority":"high"}`))
+		req := httptest.NewRequest(http.MethodPost, "/tasks", body)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "the limit is 20 bytes")
+	})
+
+	t.Run("documented as string enum", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		schema := spec.Paths["/tasks"].Post.RequestBody.Value.Content["application/json"].Schema.Value
+		priority := schema.Properties["priority"].Value
+		assert.Equal(t, "string", priority.Type)
+		assert.Equal(t, []interface{}{"low", "medium", "high"}, priority.Enum)
+	})
+}
+
+func TestOptionalEnumNullable(t *testing.T) {
+	app := echonext.New()
+	app.RegisterIntEnum(PriorityLow, map[Priority]string{
+		PriorityLow:  "low",
+		PriorityHigh: "high",
+	})
+
+	type UpdateTaskRequest struct {
+		Status   *string   `json:"status,omitempty" validate:"omitempty,oneof=open closed"`
+		Priority *Priority `json:"priority,omitempty"`
+		Kind     string    `json:"kind" validate:"oneof=bug feature"`
+	}
+
+	app.PATCH("/tasks/:id", func(c echo.Context, req UpdateTaskRequest) (Task, error) {
+		return Task{}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	schema := spec.Paths["/tasks/{id}"].Patch.RequestBody.Value.Content["application/json"].Schema.Value
+
+	status := schema.Properties["status"].Value
+	assert.True(t, status.Nullable)
+	assert.Equal(t, []interface{}{"open", "closed", nil}, status.Enum)
+
+	priority := schema.Properties["priority"].Value
+	assert.True(t, priority.Nullable)
+	assert.Equal(t, []interface{}{"low", "high", nil}, priority.Enum)
+
+	kind := schema.Properties["kind"].Value
+	assert.False(t, kind.Nullable)
+	assert.Equal(t, []interface{}{"bug", "feature"}, kind.Enum)
+}
+
+type TaskStatus string
+
+const (
+	TaskOpen   TaskStatus = "open"
+	TaskClosed TaskStatus = "closed"
+)
+
+func (TaskStatus) Values() []interface{} { return []interface{}{TaskOpen, TaskClosed} }
+
+type Severity int
+
+func (*Severity) Values() []interface{} { return []interface{}{1, 2, 3} }
+
+// Currency stands in for a type from another package
+type Currency string
+
+func TestValuesEnums(t *testing.T) {
+	type Issue struct {
+		Status     TaskStatus   `json:"status"`
+		Previous   *TaskStatus  `json:"previous,omitempty"`
+		Severity   Severity     `json:"severity"`
+		Currency   Currency     `json:"currency"`
+		Statuses   []TaskStatus `json:"statuses"`
+		Resolution string       `json:"resolution"`
+	}
+	type ListIssuesRequest struct {
+		Status TaskStatus `query:"status"`
+	}
+
+	app := echonext.New()
+	app.AddEnum(reflect.TypeOf(Currency("")), []interface{}{"usd", Currency("eur")})
+	app.POST("/issues", func(c echo.Context, req Issue) (Issue, error) { return req, nil })
+	app.GET("/issues", func(c echo.Context, req ListIssuesRequest) ([]Issue, error) { return nil, nil })
+
+	spec := app.GenerateOpenAPISpec()
+	properties := spec.Components.Schemas["Issue"].Value.Properties
+
+	assert.Equal(t, &openapi3.Schema{Type: "string", Enum: []interface{}{"open", "closed"}, Example: "open"}, properties["status"].Value)
+	assert.Equal(t, []interface{}{"open", "closed", nil}, properties["previous"].Value.Enum)
+	assert.Equal(t, "integer", properties["severity"].Value.Type)
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, properties["severity"].Value.Enum, "pointer receivers count")
+	assert.Equal(t, []interface{}{"usd", "eur"}, properties["currency"].Value.Enum)
+	assert.Equal(t, []interface{}{"open", "closed"}, properties["statuses"].Value.Items.Value.Enum)
+	assert.Nil(t, properties["resolution"].Value.Enum)
+
+	query := spec.Paths["/issues"].Get.Parameters[0].Value
+	assert.Equal(t, []interface{}{"open", "closed"}, query.Schema.Value.Enum)
+	assert.NoError(t, app.ValidateSpec())
+
+	t.Run("registered enums win", func(t *testing.T) {
+		app.AddEnum(reflect.TypeOf(TaskOpen), []interface{}{"open", "closed", "archived"})
+		properties := app.GenerateOpenAPISpec().Components.Schemas["Issue"].Value.Properties
+		assert.Equal(t, []interface{}{"open", "closed", "archived"}, properties["status"].Value.Enum)
+	})
+
+	t.Run("invalid registrations", func(t *testing.T) {
+		assert.Panics(t, func() { app.AddEnum(reflect.TypeOf(Issue{}), []interface{}{"a"}) })
+		assert.Panics(t, func() { app.AddEnum(reflect.TypeOf(Currency("")), nil) })
+		assert.Panics(t, func() { app.AddEnum(reflect.TypeOf(Severity(0)), []interface{}{"high"}) })
+	})
+}
@@ -0,0 +1,162 @@
+package echonext
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// envelopeKey holds the app's envelope for the request
+const envelopeKey = "echonext.envelope"
+
+// Envelope shapes response bodies. Success wraps a handler's result and Error
+// wraps a failure; the schema methods document those shapes in the spec, given
+// the schema of the data or of one error detail. When building spec examples,
+// Success is called with a context holding a placeholder GET / request.
+type Envelope interface {
+	Success(c echo.Context, data interface{}) interface{}
+	Error(c echo.Context, status int, message string, details []FieldError) interface{}
+	SuccessSchema(data *openapi3.SchemaRef) *openapi3.SchemaRef
+	ErrorSchema(detail *openapi3.SchemaRef) *openapi3.SchemaRef
+}
+
+// StandardEnvelope is the default {success, data, error} envelope
+type StandardEnvelope struct{}
+
+// Success wraps data as {"data": ..., "success": true}
+func (StandardEnvelope) Success(c echo.Context, data interface{}) interface{} {
+	return Response[any]{Data: data, Success: true, Warnings: requestWarnings(c)}
+}
+
+// Error wraps a failure as {"error": ..., "success": false}
+func (StandardEnvelope) Error(c echo.Context, status int, message string, details []FieldError) interface{} {
+	return Response[any]{
+		Error:         message,
+		Success:       false,
+		CorrelationID: Correlation(c).RequestID,
+		Details:       details,
+	}
+}
+
+// SuccessSchema documents Response with data
+func (StandardEnvelope) SuccessSchema(data *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: "object",
+		Properties: openapi3.Schemas{
+			"success": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "boolean"},
+			},
+			"data": data,
+			"error": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "string"},
+			},
+			"warnings": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{
+					Type:        "array",
+					Items:       &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string"}},
+					Description: "Non-fatal warnings about the request",
+				},
+			},
+		},
+	}}
+}
+
+// ErrorSchema documents Response with an error
+func (StandardEnvelope) ErrorSchema(detail *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: "object",
+		Properties: openapi3.Schemas{
+			"success": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "boolean", Default: false},
+			},
+			"error": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "string"},
+			},
+			"correlation_id": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "string"},
+			},
+			"details": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "array", Items: detail},
+			},
+		},
+	}}
+}
+
+// NoEnvelope sends handler results as they are and errors as
+// {"error": ..., "details": [...]}
+type NoEnvelope struct{}
+
+// Success returns data unchanged
+func (NoEnvelope) Success(c echo.Context, data interface{}) interface{} {
+	return data
+}
+
+// Error returns a bare error body
+func (NoEnvelope) Error(c echo.Context, status int, message string, details []FieldError) interface{} {
+	return rawError{
+		Error:         message,
+		CorrelationID: Correlation(c).RequestID,
+		Details:       details,
+	}
+}
+
+// SuccessSchema documents the data itself
+func (NoEnvelope) SuccessSchema(data *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return data
+}
+
+// ErrorSchema documents the bare error body
+func (NoEnvelope) ErrorSchema(detail *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type:     "object",
+		Required: []string{"error"},
+		Properties: openapi3.Schemas{
+			"error": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "string"},
+			},
+			"correlation_id": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "string"},
+			},
+			"details": &openapi3.SchemaRef{
+				Value: &openapi3.Schema{Type: "array", Items: detail},
+			},
+		},
+	}}
+}
+
+// SetEnvelope replaces the response envelope for every route. Pass
+// NoEnvelope{} to send handler results unwrapped.
+func (app *App) SetEnvelope(envelope Envelope) {
+	if envelope == nil {
+		panic("echonext: envelope must not be nil")
+	}
+	app.envelope = envelope
+	app.invalidateSchemas()
+}
+
+// envelopeFor returns the envelope for the request. Callers opting out of
+// the envelope get NoEnvelope regardless of the app's setting.
+func envelopeFor(c echo.Context) Envelope {
+	if wantsRaw(c) {
+		return NoEnvelope{}
+	}
+	if envelope, ok := c.Get(envelopeKey).(Envelope); ok {
+		return envelope
+	}
+	return StandardEnvelope{}
+}
+
+// envelopeExample wraps a documented response example in the envelope.
+// Custom envelopes get a placeholder request to read, as there is none.
+func (app *App) envelopeExample(example interface{}) interface{} {
+	switch app.envelope.(type) {
+	case StandardEnvelope:
+		return map[string]interface{}{"success": true, "data": example}
+	case NoEnvelope:
+		return example
+	}
+	c := app.Echo.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	return app.envelope.Success(c, example)
+}
@@ -0,0 +1,120 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonAPIEnvelope wraps results JSON:API style
+type jsonAPIEnvelope struct{}
+
+func (jsonAPIEnvelope) Success(c echo.Context, data interface{}) interface{} {
+	return map[string]interface{}{"data": data}
+}
+
+func (jsonAPIEnvelope) Error(c echo.Context, status int, message string, details []echonext.FieldError) interface{} {
+	return map[string]interface{}{"errors": []map[string]interface{}{{"status": status, "title": message}}}
+}
+
+func (jsonAPIEnvelope) SuccessSchema(data *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "object", Properties: openapi3.Schemas{"data": data}}}
+}
+
+func (jsonAPIEnvelope) ErrorSchema(detail *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "object", Properties: openapi3.Schemas{
+		"errors": &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "array"}},
+	}}}
+}
+
+func TestEnvelope(t *testing.T) {
+	setup := func(envelope echonext.Envelope) *echonext.App {
+		app := echonext.New()
+		app.SetEnvelope(envelope)
+		app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+			if c.Param("id") == "missing" {
+				return TestUser{}, echo.NewHTTPError(http.StatusNotFound, "user not found")
+			}
+			return TestUser{ID: c.Param("id"), Name: "Ada"}, nil
+		})
+		return app
+	}
+	serve := func(app *echonext.App, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("none", func(t *testing.T) {
+		app := setup(echonext.NoEnvelope{})
+
+		assert.JSONEq(t, `{"id":"1","name":"Ada","email":""}`, serve(app, "/users/1").Body.String())
+		assert.JSONEq(t, `{"error":"user not found"}`, serve(app, "/users/missing").Body.String())
+
+		responses := app.GenerateOpenAPISpec().Paths["/users/{id}"].Get.Responses
+		assert.Equal(t, "#/components/schemas/TestUser", responses["200"].Value.Content["application/json"].Schema.Ref)
+		errorSchema := responses["400"].Value.Content["application/json"].Schema.Value
+		assert.Contains(t, errorSchema.Properties, "error")
+		assert.NotContains(t, errorSchema.Properties, "success")
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		app := setup(jsonAPIEnvelope{})
+
+		assert.JSONEq(t, `{"data":{"id":"1","name":"Ada","email":""}}`, serve(app, "/users/1").Body.String())
+		assert.JSONEq(t, `{"errors":[{"status":404,"title":"user not found"}]}`, serve(app, "/users/missing").Body.String())
+
+		responses := app.GenerateOpenAPISpec().Paths["/users/{id}"].Get.Responses
+		success := responses["200"].Value.Content["application/json"].Schema.Value
+		assert.Equal(t, "#/components/schemas/TestUser", success.Properties["data"].Ref)
+		assert.Contains(t, responses["400"].Value.Content["application/json"].Schema.Value.Properties, "errors")
+	})
+
+	t.Run("errors outside handlers", func(t *testing.T) {
+		app := setup(echonext.NoEnvelope{})
+		app.POST("/limited", func(c echo.Context) error { return nil }, echonext.Route{
+			RateLimit: &echonext.RateLimit{Requests: 1},
+		})
+
+		req := func() *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/limited", nil))
+			return rec
+		}
+		req()
+		rec := req()
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "success")
+	})
+}
+
+// selfLinkEnvelope reads the request to link each result to itself
+type selfLinkEnvelope struct{ jsonAPIEnvelope }
+
+func (selfLinkEnvelope) Success(c echo.Context, data interface{}) interface{} {
+	return map[string]interface{}{"data": data, "links": map[string]string{"self": c.Request().URL.Path}}
+}
+
+func TestEnvelopeExampleReadsRequest(t *testing.T) {
+	app := echonext.New()
+	app.SetEnvelope(selfLinkEnvelope{})
+	app.SetResponseExampleProvider(func(t reflect.Type) (interface{}, bool) {
+		return TestUser{ID: "1"}, t == reflect.TypeOf(TestUser{})
+	})
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		return TestUser{ID: c.Param("id")}, nil
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	example, ok := spec.Paths["/users/{id}"].Get.Responses["200"].Value.Content["application/json"].Example.(map[string]interface{})
+	if assert.True(t, ok) {
+		assert.Equal(t, map[string]string{"self": "/"}, example["links"])
+	}
+}
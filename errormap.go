@@ -0,0 +1,41 @@
+package echonext
+
+import "github.com/labstack/echo/v4"
+
+// errorMapperKey holds the app's error mapper for the request
+const errorMapperKey = "echonext.errormapper"
+
+// SetErrorMapper translates errors returned by handlers, dependency providers
+// and typed middleware into a status and message, e.g. ErrNotFound into 404.
+// It is consulted before the built-in handling of *echo.HTTPError,
+// StatusCoder and timeouts; returning false leaves an error to those. An
+// empty message falls back to the error's.
+//
+//	app.SetErrorMapper(func(err error) (int, string, bool) {
+//		switch {
+//		case errors.Is(err, store.ErrNotFound):
+//			return http.StatusNotFound, "", true
+//		case errors.Is(err, store.ErrConflict):
+//			return http.StatusConflict, "Already exists", true
+//		}
+//		return 0, "", false
+//	})
+func (app *App) SetErrorMapper(mapper func(err error) (status int, message string, ok bool)) {
+	app.errorMapper = mapper
+}
+
+// mapError applies the app's error mapper to err
+func mapError(c echo.Context, err error) (int, string, bool) {
+	mapper, _ := c.Get(errorMapperKey).(func(error) (int, string, bool))
+	if mapper == nil {
+		return 0, "", false
+	}
+	status, message, ok := mapper(err)
+	if !ok || status == 0 {
+		return 0, "", false
+	}
+	if message == "" {
+		message = err.Error()
+	}
+	return status, message, true
+}
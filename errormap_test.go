@@ -0,0 +1,73 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errNotFound = errors.New("todo not found")
+	errConflict = errors.New("todo exists")
+)
+
+func TestErrorMapper(t *testing.T) {
+	app := echonext.New()
+	app.SetErrorMapper(func(err error) (int, string, bool) {
+		var he *echo.HTTPError
+		switch {
+		case errors.Is(err, errNotFound):
+			return http.StatusNotFound, "", true
+		case errors.Is(err, errConflict):
+			return http.StatusConflict, "Already exists", true
+		case errors.As(err, &he) && he.Code == http.StatusBadRequest:
+			return http.StatusUnprocessableEntity, fmt.Sprint(he.Message), true
+		}
+		return 0, "", false
+	})
+
+	fail := func(err error) func(c echo.Context) (TestUser, error) {
+		return func(c echo.Context) (TestUser, error) { return TestUser{}, err }
+	}
+	app.GET("/missing", fail(fmt.Errorf("loading todo 7: %w", errNotFound)))
+	app.GET("/conflict", fail(errConflict))
+	app.GET("/invalid", fail(echo.NewHTTPError(http.StatusBadRequest, "bad title")))
+	app.GET("/http", fail(echo.NewHTTPError(http.StatusForbidden, "nope")))
+	app.GET("/other", fail(errors.New("boom")))
+	app.GET("/guarded", fail(nil), echonext.Route{Middleware: []echo.MiddlewareFunc{
+		echonext.Middleware(func(c echo.Context) (bool, int, any, error) { return false, 0, nil, errNotFound }),
+	}})
+
+	tests := []struct {
+		path    string
+		status  int
+		message string
+	}{
+		{"/missing", http.StatusNotFound, "loading todo 7: todo not found"},
+		{"/conflict", http.StatusConflict, "Already exists"},
+		{"/invalid", http.StatusUnprocessableEntity, "bad title"},
+		{"/http", http.StatusForbidden, "nope"},
+		{"/other", http.StatusInternalServerError, "boom"},
+		{"/guarded", http.StatusNotFound, "todo not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.status, rec.Code)
+
+			var response echonext.Response[any]
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, tt.message, response.Error)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package echonext
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field    string `json:"field" xml:"field"`
+	In       string `json:"in,omitempty" xml:"in,omitempty"` // "query", "path", "header" or "body"
+	Tag      string `json:"tag,omitempty" xml:"tag,omitempty"`
+	Param    string `json:"param,omitempty" xml:"param,omitempty"`
+	Expected string `json:"expected,omitempty" xml:"expected,omitempty"`
+	Value    string `json:"value,omitempty" xml:"value,omitempty"`
+	Message  string `json:"message" xml:"message"`
+}
+
+// StatusCoder is implemented by errors that know their HTTP status. Handlers
+// returning such an error respond with that status and the error's message.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// errorResponseWithDetails writes an error envelope carrying per-field details
+func errorResponseWithDetails(c echo.Context, status int, message string, details []FieldError) error {
+	return respond(c, status, envelopeFor(c).Error(c, status, message, details))
+}
+
+// paramTypeErrors explains query or path binding failures by checking each
+// parameter against the kind of the field it binds into. tag is the struct tag
+// naming the parameter and in is where it was sent.
+func paramTypeErrors(t reflect.Type, tag, in string, values url.Values) []FieldError {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var details []FieldError
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get(tag)
+		if name == "" || name == "-" {
+			continue
+		}
+		// Delimited path slices are checked element by element when split
+		if in == "path" && field.Type.Kind() == reflect.Slice {
+			continue
+		}
+
+		elemType := field.Type
+		for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice {
+			elemType = elemType.Elem()
+		}
+		if scalarTypeName(elemType.Kind()) == "" {
+			continue
+		}
+
+		for _, raw := range values[name] {
+			if err := setScalar(reflect.New(elemType).Elem(), raw); err != nil {
+				if isSensitive(field) {
+					raw = RedactedValue
+				}
+				details = append(details, numberError(name, in, elemType, raw, err))
+				break
+			}
+		}
+	}
+	return details
+}
+
+// jsonTypeErrors explains a JSON body that failed to decode because a value
+// didn't fit the field it was bound into. requestType resolves the field, so
+// it is named as on the wire and sensitive values are redacted.
+func (app *App) jsonTypeErrors(err error, requestType reflect.Type) []FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return nil
+	}
+	t := typeErr.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name, field, resolved := app.bodyField(requestType, typeErr.Field)
+
+	raw, isNumber := strings.CutPrefix(typeErr.Value, "number ")
+	if isNumber && scalarTypeName(t.Kind()) != "" {
+		parseErr := setScalar(reflect.New(t).Elem(), raw)
+		if resolved && isSensitive(field) {
+			raw = RedactedValue
+		}
+		return []FieldError{numberError(name, "body", t, raw, parseErr)}
+	}
+
+	// Only the kind of the value sent is named, never the value itself
+	got, _, _ := strings.Cut(typeErr.Value, " ")
+	expected := jsonTypeName(t)
+	return []FieldError{{
+		Field:    name,
+		In:       "body",
+		Expected: expected,
+		Message:  fmt.Sprintf("%s must be %s %s, got %s", name, article(expected), expected, got),
+	}}
+}
+
+// jsonTypeName names the kind of JSON value a type decodes from
+func jsonTypeName(t reflect.Type) string {
+	if name := scalarTypeName(t.Kind()); name != "" {
+		return name
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return "array"
+		}
+	}
+	return "string"
+}
+
+// bodyField resolves the path of a JSON decoding error, JSON names separated
+// by dots with embedded structs named by their type, to the struct field it
+// names and its path on the wire under the naming strategy. Unresolved paths
+// are returned as they are.
+func (app *App) bodyField(t reflect.Type, path string) (string, reflect.StructField, bool) {
+	var field reflect.StructField
+	var names []string
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return path, reflect.StructField{}, false
+		}
+		var ok bool
+		if field, ok = jsonField(t, name); !ok {
+			return path, reflect.StructField{}, false
+		}
+		t = field.Type
+
+		// Embedded structs are flattened, as in JSON
+		if !isEmbeddedStruct(field) {
+			wireName, _ := app.naming.fieldName(field)
+			names = append(names, wireName)
+		}
+	}
+	return strings.Join(names, "."), field, true
+}
+
+// jsonField returns the field of struct t decoded from the JSON property
+// name, or the embedded struct of that type name
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isEmbeddedStruct(field) {
+			if field.Name == name {
+				return field, true
+			}
+			continue
+		}
+		if jsonName, ok := jsonFieldName(field); ok && jsonName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// numberError describes raw failing to parse into a value of type t, naming
+// the valid range when raw overflowed it
+func numberError(name, in string, t reflect.Type, raw string, err error) FieldError {
+	expected := scalarTypeName(t.Kind())
+	detail := FieldError{
+		Field:    name,
+		In:       in,
+		Expected: expected,
+		Value:    raw,
+		Message:  fmt.Sprintf("%s must be %s %s, got %q", name, article(expected), expected, raw),
+	}
+	if lo, hi, ok := numericRange(t); ok && errors.Is(err, strconv.ErrRange) {
+		detail.Message = fmt.Sprintf("%s must be %s %s between %s and %s, got %q", name, article(expected), expected, lo, hi, raw)
+	}
+	return detail
+}
+
+// numericRange returns the smallest and largest values of a numeric type
+func numericRange(t reflect.Type) (lo, hi string, ok bool) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := 64 - t.Bits()
+		return strconv.FormatInt(math.MinInt64>>shift, 10), strconv.FormatInt(math.MaxInt64>>shift, 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "0", strconv.FormatUint(math.MaxUint64>>(64-t.Bits()), 10), true
+	case reflect.Float32:
+		return strconv.FormatFloat(-math.MaxFloat32, 'g', -1, 32), strconv.FormatFloat(math.MaxFloat32, 'g', -1, 32), true
+	case reflect.Float64:
+		return strconv.FormatFloat(-math.MaxFloat64, 'g', -1, 64), strconv.FormatFloat(math.MaxFloat64, 'g', -1, 64), true
+	}
+	return "", "", false
+}
+
+// scalarTypeName returns the OpenAPI type name of a basic kind
+func scalarTypeName(k reflect.Kind) string {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	}
+	return ""
+}
+
+func article(word string) string {
+	if strings.ContainsAny(word[:1], "aeiou") {
+		return "an"
+	}
+	return "a"
+}
+
+// fieldErrorsMessage joins the messages of details for the top-level error string
+func fieldErrorsMessage(details []FieldError) string {
+	messages := make([]string, len(details))
+	for i, detail := range details {
+		messages[i] = detail.Message
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,140 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTypeErrors(t *testing.T) {
+	app := echonext.New()
+
+	type ListRequest struct {
+		Page  int    `query:"page"`
+		Sort  string `query:"sort"`
+		Exact bool   `query:"exact"`
+	}
+
+	app.GET("/items", func(c echo.Context, req ListRequest) ([]TestUser, error) {
+		return []TestUser{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items?page=abc&sort=name", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Error, "Invalid query parameters")
+	assert.Contains(t, response.Error, "page")
+	assert.Equal(t, []echonext.FieldError{{
+		Field:    "page",
+		In:       "query",
+		Expected: "integer",
+		Value:    "abc",
+		Message:  `page must be an integer, got "abc"`,
+	}}, response.Details)
+}
+
+func TestNumericOverflowErrors(t *testing.T) {
+	app := echonext.New()
+
+	type ListRequest struct {
+		Limit int `query:"limit"`
+	}
+	type GetRequest struct {
+		Shard int8 `param:"shard"`
+	}
+	type CreateRequest struct {
+		Quantity uint16 `json:"quantity"`
+	}
+
+	app.GET("/items", func(c echo.Context, req ListRequest) ([]TestUser, error) {
+		return []TestUser{}, nil
+	})
+	app.GET("/shards/:shard", func(c echo.Context, req GetRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+	app.POST("/orders", func(c echo.Context, req CreateRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	send := func(method, path, body string) echonext.Response[any] {
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("query", func(t *testing.T) {
+		response := send(http.MethodGet, "/items?limit=99999999999999999999", "")
+		assert.Equal(t, []echonext.FieldError{{
+			Field:    "limit",
+			In:       "query",
+			Expected: "integer",
+			Value:    "99999999999999999999",
+			Message:  `limit must be an integer between -9223372036854775808 and 9223372036854775807, got "99999999999999999999"`,
+		}}, response.Details)
+		assert.Contains(t, response.Error, "Invalid query parameters")
+	})
+
+	t.Run("path", func(t *testing.T) {
+		response := send(http.MethodGet, "/shards/300", "")
+		if assert.Len(t, response.Details, 1) {
+			assert.Equal(t, "path", response.Details[0].In)
+			assert.Equal(t, `shard must be an integer between -128 and 127, got "300"`, response.Details[0].Message)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		response := send(http.MethodPost, "/orders", `{"quantity":70000}`)
+		if assert.Len(t, response.Details, 1) {
+			assert.Equal(t, "body", response.Details[0].In)
+			assert.Equal(t, `quantity must be an integer between 0 and 65535, got "70000"`, response.Details[0].Message)
+		}
+	})
+
+	t.Run("json truncation", func(t *testing.T) {
+		response := send(http.MethodPost, "/orders", `{"quantity":1.5}`)
+		if assert.Len(t, response.Details, 1) {
+			assert.Equal(t, `quantity must be an integer, got "1.5"`, response.Details[0].Message)
+		}
+	})
+}
+
+type conflictError struct{ resource string }
+
+func (e conflictError) Error() string   { return e.resource + " already exists" }
+func (e conflictError) StatusCode() int { return http.StatusConflict }
+
+func TestStatusCoderErrors(t *testing.T) {
+	app := echonext.New()
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{}, fmt.Errorf("create user: %w", conflictError{resource: "user"})
+	})
+
+	body, _ := json.Marshal(CreateUserRequest{Name: "John", Email: "john@example.com"})
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "create user: user already exists", response.Error)
+}
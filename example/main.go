@@ -33,19 +33,12 @@ type UpdateTodoRequest struct {
 }
 
 type ListTodosRequest struct {
-	Page      int    `query:"page" validate:"min=1"`
-	Limit     int    `query:"limit" validate:"min=1,max=100"`
+	Page      int    `query:"page" default:"1" validate:"min=1"`
+	Limit     int    `query:"limit" default:"10" validate:"min=1,max=100"`
 	Completed *bool  `query:"completed"`
 	Sort      string `query:"sort" validate:"omitempty,oneof=created_at updated_at title"`
 }
 
-type ListTodosResponse struct {
-	Todos      []Todo `json:"todos"`
-	TotalCount int    `json:"total_count"`
-	Page       int    `json:"page"`
-	Limit      int    `json:"limit"`
-}
-
 // In-memory storage
 var todos = make(map[string]*Todo)
 
@@ -189,15 +182,7 @@ func createTodo(c echo.Context, req CreateTodoRequest) (Todo, error) {
 	return todo, nil
 }
 
-func listTodos(c echo.Context, req ListTodosRequest) (ListTodosResponse, error) {
-	// Set defaults
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.Limit == 0 {
-		req.Limit = 10
-	}
-
+func listTodos(c echo.Context, req ListTodosRequest) (echonext.Page[Todo], error) {
 	// Filter todos
 	var filteredTodos []Todo
 	for _, todo := range todos {
@@ -217,12 +202,7 @@ func listTodos(c echo.Context, req ListTodosRequest) (ListTodosResponse, error)
 		start = len(filteredTodos)
 	}
 
-	return ListTodosResponse{
-		Todos:      filteredTodos[start:end],
-		TotalCount: len(filteredTodos),
-		Page:       req.Page,
-		Limit:      req.Limit,
-	}, nil
+	return echonext.Paginate(c, filteredTodos[start:end], len(filteredTodos), req.Page, req.Limit), nil
 }
 
 func getTodo(c echo.Context) (Todo, error) {
@@ -245,7 +225,8 @@ func updateTodo(c echo.Context, req UpdateTodoRequest) (Todo, error) {
 	if req.Title != "" {
 		todo.Title = req.Title
 	}
-	if req.Description != "" {
+	// An empty description clears it; an omitted one leaves it unchanged
+	if echonext.Provided(c, "description") {
 		todo.Description = req.Description
 	}
 	if req.Completed != nil {
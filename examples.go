@@ -0,0 +1,152 @@
+package echonext
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SetResponseExampleProvider supplies representative values, e.g. from
+// fixtures, that are attached as examples to success responses. The provider
+// reports false for types it has no example for.
+func (app *App) SetResponseExampleProvider(provider func(t reflect.Type) (interface{}, bool)) {
+	app.exampleProvider = provider
+}
+
+// responseExample returns the example for t. List responses without their
+// own example use a single-item list of the element example.
+func (app *App) responseExample(t reflect.Type) (interface{}, bool) {
+	if app.exampleProvider == nil {
+		return nil, false
+	}
+	if example, ok := app.exampleProvider(t); ok {
+		return example, true
+	}
+	if t.Kind() == reflect.Slice {
+		if example, ok := app.exampleProvider(t.Elem()); ok {
+			return []interface{}{example}, true
+		}
+	}
+	return nil, false
+}
+
+// tagExample converts an example tag to the type of its field, so
+// `example:"30"` on an int is documented as 30. Lists may be written as JSON
+// or comma-separated, maps and structs as JSON. Values that don't parse, and
+// times and named int enums, are documented as written.
+func (app *App) tagExample(t reflect.Type, tag string) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := app.intEnums[t]; ok {
+		return tag
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(tag, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(tag, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(tag, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(tag); err == nil {
+			return b
+		}
+	case reflect.Slice, reflect.Array:
+		var items []interface{}
+		if json.Unmarshal([]byte(tag), &items) == nil {
+			return items
+		}
+		for _, item := range strings.Split(tag, ",") {
+			items = append(items, app.tagExample(t.Elem(), strings.TrimSpace(item)))
+		}
+		return items
+	case reflect.Map, reflect.Struct:
+		if t == timeType {
+			return tag
+		}
+		var value interface{}
+		if json.Unmarshal([]byte(tag), &value) == nil {
+			return value
+		}
+	}
+	return tag
+}
+
+// typeExample returns a placeholder for a scalar schema without an example:
+// its first enum value or a value of its type and format within its bounds.
+// It returns nil for other schemas and for patterned strings.
+func typeExample(schema *openapi3.Schema) interface{} {
+	for _, value := range schema.Enum {
+		if value != nil {
+			return value
+		}
+	}
+
+	switch schema.Type {
+	case "integer":
+		return int64(boundedNumber(schema, 1))
+	case "number":
+		return boundedNumber(schema, 0.5)
+	case "boolean":
+		return false
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "uri", "url":
+			return "https://example.com"
+		case "binary":
+			return nil
+		}
+		if schema.Pattern != "" {
+			return nil
+		}
+		example := "string"
+		if schema.MaxLength != nil && uint64(len(example)) > *schema.MaxLength {
+			example = example[:*schema.MaxLength]
+		}
+		if n := int(schema.MinLength) - len(example); n > 0 {
+			example += strings.Repeat("x", n)
+		}
+		return example
+	}
+	return nil
+}
+
+// boundedNumber returns 0, or the bound nearest to it when 0 is out of
+// range, stepping inside exclusive bounds
+func boundedNumber(schema *openapi3.Schema, step float64) float64 {
+	switch {
+	case schema.Min != nil && (*schema.Min > 0 || *schema.Min == 0 && schema.ExclusiveMin):
+		n := math.Ceil(*schema.Min/step) * step
+		if schema.ExclusiveMin && n == *schema.Min {
+			n += step
+		}
+		return n
+	case schema.Max != nil && (*schema.Max < 0 || *schema.Max == 0 && schema.ExclusiveMax):
+		n := math.Floor(*schema.Max/step) * step
+		if schema.ExclusiveMax && n == *schema.Max {
+			n -= step
+		}
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package echonext_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Todo struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+func TestResponseExampleProvider(t *testing.T) {
+	seed := Todo{ID: "1", Title: "Buy milk"}
+
+	app := echonext.New()
+	app.SetResponseExampleProvider(func(t reflect.Type) (interface{}, bool) {
+		if t == reflect.TypeOf(Todo{}) {
+			return seed, true
+		}
+		return nil, false
+	})
+
+	app.GET("/todos/:id", func(c echo.Context) (Todo, error) { return seed, nil })
+	app.GET("/todos", func(c echo.Context) ([]Todo, error) { return nil, nil })
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) { return TestUser{}, nil })
+
+	spec := app.GenerateOpenAPISpec()
+	example := func(path string) interface{} {
+		return spec.Paths[path].Get.Responses["200"].Value.Content["application/json"].Example
+	}
+
+	assert.Equal(t, map[string]interface{}{"success": true, "data": seed}, example("/todos/{id}"))
+	assert.Equal(t, map[string]interface{}{"success": true, "data": []interface{}{seed}}, example("/todos"))
+	assert.Nil(t, example("/users/{id}"))
+}
+
+type Member struct {
+	Age       int               `json:"age" example:"30"`
+	Score     float64           `json:"score" example:"4.5"`
+	Active    bool              `json:"active" example:"true"`
+	Labels    []string          `json:"labels" example:"red,blue"`
+	Ratings   []int             `json:"ratings" example:"[1, 2]"`
+	Meta      map[string]string `json:"meta" example:"{\"plan\":\"pro\"}"`
+	Nickname  *string           `json:"nickname" example:"ada"`
+	Born      time.Time         `json:"born" example:"1815-12-10T00:00:00Z"`
+	Name      string            `json:"name" validate:"min=8"`
+	Code      string            `json:"code" validate:"max=3"`
+	Email     string            `json:"email" validate:"email"`
+	Role      string            `json:"role" validate:"oneof=admin member"`
+	Count     int               `json:"count" validate:"gt=0"`
+	Ratio     float64           `json:"ratio"`
+	Verified  bool              `json:"verified"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Tags      []string          `json:"tags"`
+}
+
+func TestSchemaExamples(t *testing.T) {
+	app := echonext.New()
+	app.POST("/members", func(c echo.Context, m Member) (Member, error) { return m, nil })
+
+	properties := app.GenerateOpenAPISpec().Components.Schemas["Member"].Value.Properties
+	example := func(name string) interface{} {
+		return properties[name].Value.Example
+	}
+
+	t.Run("tags are typed", func(t *testing.T) {
+		assert.Equal(t, int64(30), example("age"))
+		assert.Equal(t, 4.5, example("score"))
+		assert.Equal(t, true, example("active"))
+		assert.Equal(t, []interface{}{"red", "blue"}, example("labels"))
+		assert.Equal(t, []interface{}{float64(1), float64(2)}, example("ratings"))
+		assert.Equal(t, map[string]interface{}{"plan": "pro"}, example("meta"))
+		assert.Equal(t, "ada", example("nickname"))
+		assert.Equal(t, "1815-12-10T00:00:00Z", example("born"))
+	})
+
+	t.Run("untagged scalars", func(t *testing.T) {
+		assert.Equal(t, "stringxx", example("name"))
+		assert.Equal(t, "str", example("code"))
+		assert.Equal(t, "user@example.com", example("email"))
+		assert.Equal(t, "admin", example("role"))
+		assert.Equal(t, int64(1), example("count"))
+		assert.Equal(t, float64(0), example("ratio"))
+		assert.Equal(t, false, example("verified"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", example("updated_at"))
+		assert.Nil(t, example("tags"))
+	})
+
+	assert.NoError(t, app.ValidateSpec())
+
+	t.Run("unparseable tags", func(t *testing.T) {
+		type Level struct {
+			Level int `json:"level" example:"high"`
+		}
+		app := echonext.New()
+		app.POST("/levels", func(c echo.Context, l Level) error { return nil })
+
+		assert.Equal(t, "high", app.GenerateOpenAPISpec().Components.Schemas["Level"].Value.Properties["level"].Value.Example)
+		assert.ErrorContains(t, app.ValidateSpec(), "value must be an integer")
+	})
+}
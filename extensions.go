@@ -0,0 +1,26 @@
+package echonext
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetSpecExtension sets a vendor extension on the spec document, such as
+// x-tagGroups. Keys must start with "x-".
+func (app *App) SetSpecExtension(key string, value interface{}) {
+	checkExtensionKey(key)
+	setExtension(&app.spec.Extensions, key, value)
+}
+
+// checkExtensions panics on extension keys OpenAPI doesn't allow
+func checkExtensions(extensions map[string]interface{}) {
+	for key := range extensions {
+		checkExtensionKey(key)
+	}
+}
+
+func checkExtensionKey(key string) {
+	if !strings.HasPrefix(key, "x-") {
+		panic(fmt.Sprintf("echonext: extension %q must start with x-", key))
+	}
+}
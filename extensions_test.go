@@ -0,0 +1,60 @@
+package echonext_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecExtensions(t *testing.T) {
+	app := echonext.New()
+	app.SetSpecExtension("x-tagGroups", []map[string]interface{}{{"name": "Users", "tags": []string{"users"}}})
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil }, echonext.Route{
+		Extensions: map[string]interface{}{
+			"x-internal": true,
+			"x-amazon-apigateway-integration": map[string]interface{}{
+				"type":       "http_proxy",
+				"httpMethod": "GET",
+			},
+		},
+	})
+	app.GET("/public", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+
+	data, err := app.MarshalOpenAPISpec()
+	require.NoError(t, err)
+	var doc struct {
+		TagGroups []map[string]interface{} `json:"x-tagGroups"`
+		Paths     map[string]map[string]map[string]interface{}
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, "Users", doc.TagGroups[0]["name"])
+	operation := doc.Paths["/users"]["get"]
+	assert.Equal(t, true, operation["x-internal"])
+	assert.Equal(t, map[string]interface{}{"type": "http_proxy", "httpMethod": "GET"}, operation["x-amazon-apigateway-integration"])
+	assert.NotContains(t, doc.Paths["/public"]["get"], "x-internal")
+
+	assert.NoError(t, app.GenerateOpenAPISpec().Validate(context.Background()))
+
+	t.Run("OpenAPI 3.1", func(t *testing.T) {
+		app.SetOpenAPIVersion(echonext.OpenAPI31)
+		data, err := app.MarshalOpenAPISpec()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"x-internal":true`)
+		assert.Contains(t, string(data), `"x-tagGroups"`)
+	})
+
+	t.Run("keys must start with x-", func(t *testing.T) {
+		assert.PanicsWithValue(t, `echonext: extension "internal" must start with x-`, func() {
+			echonext.New().GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil }, echonext.Route{
+				Extensions: map[string]interface{}{"internal": true},
+			})
+		})
+		assert.Panics(t, func() { echonext.New().SetSpecExtension("tagGroups", nil) })
+	})
+}
@@ -0,0 +1,25 @@
+package echonext
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+const featuresKey = "echonext.features"
+
+// SetFeatureProvider resolves feature flags once per request, e.g. from
+// headers, user claims or a flag service. Handlers read them with Feature.
+func (app *App) SetFeatureProvider(provider func(c echo.Context) map[string]bool) {
+	app.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(featuresKey, provider(c))
+			return next(c)
+		}
+	})
+}
+
+// Feature reports whether the named flag is enabled for the current request.
+// Flags are off when no provider is set or the provider omits them.
+func Feature(c echo.Context, name string) bool {
+	flags, _ := c.Get(featuresKey).(map[string]bool)
+	return flags[name]
+}
@@ -0,0 +1,57 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Profile struct {
+	Name  string `json:"name"`
+	Badge string `json:"badge,omitempty"`
+}
+
+func TestFeatureFlags(t *testing.T) {
+	app := echonext.New()
+	app.SetFeatureProvider(func(c echo.Context) map[string]bool {
+		return map[string]bool{"badges": c.Request().Header.Get("X-Beta") == "1"}
+	})
+
+	app.GET("/profile", func(c echo.Context) (Profile, error) {
+		profile := Profile{Name: "John"}
+		if echonext.Feature(c, "badges") {
+			profile.Badge = "early-adopter"
+		}
+		return profile, nil
+	}, echonext.Route{Features: []string{"badges"}})
+
+	get := func(beta bool) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+		if beta {
+			req.Header.Set("X-Beta", "1")
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response echonext.Response[map[string]interface{}]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	assert.NotContains(t, get(false), "badge")
+	assert.Equal(t, "early-adopter", get(true)["badge"])
+
+	spec := app.GenerateOpenAPISpec()
+	assert.Equal(t, []string{"badges"}, spec.Paths["/profile"].Get.Extensions["x-feature-flags"])
+}
+
+func TestFeatureWithoutProvider(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.False(t, echonext.Feature(c, "badges"))
+}
@@ -0,0 +1,231 @@
+package echonext
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// maxFormIndex bounds indexed form names like items[3] so a request cannot
+// allocate arbitrarily large slices
+const maxFormIndex = 1000
+
+// isFormRequest reports whether the request body is a form
+func isFormRequest(c echo.Context) bool {
+	ctype := c.Request().Header.Get(echo.HeaderContentType)
+	return strings.HasPrefix(ctype, echo.MIMEApplicationForm) || strings.HasPrefix(ctype, echo.MIMEMultipartForm)
+}
+
+// formSegment is one step of a nested form name: "items[2]" or "name"
+type formSegment struct {
+	name  string
+	index int // -1 when not indexed
+}
+
+// parseFormName splits names like "user.address.city" or "items[0].name"
+func parseFormName(name string) ([]formSegment, bool) {
+	var segments []formSegment
+	for _, part := range strings.Split(name, ".") {
+		segment := formSegment{name: part, index: -1}
+		if open := strings.Index(part, "["); open >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, false
+			}
+			index, err := strconv.Atoi(part[open+1 : len(part)-1])
+			if err != nil || index < 0 {
+				return nil, false
+			}
+			segment = formSegment{name: part[:open], index: index}
+		}
+		if segment.name == "" {
+			return nil, false
+		}
+		segments = append(segments, segment)
+	}
+	return segments, true
+}
+
+// bindNestedForm binds dotted and indexed form names into nested structs and
+// slices. Flat names are left to Echo's form binding.
+func bindNestedForm(values url.Values, target reflect.Value) error {
+	for name, vals := range values {
+		if !strings.ContainsAny(name, ".[") {
+			continue
+		}
+		segments, ok := parseFormName(name)
+		if !ok {
+			continue
+		}
+		if err := setFormValue(target, segments, vals); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setFormValue(v reflect.Value, segments []formSegment, vals []string) error {
+	v = allocElem(v)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	field, ok := formField(v, segments[0].name)
+	if !ok {
+		return nil
+	}
+
+	if index := segments[0].index; index >= 0 {
+		field = allocElem(field)
+		if field.Kind() != reflect.Slice {
+			return fmt.Errorf("not a list")
+		}
+		if index >= maxFormIndex {
+			return fmt.Errorf("index exceeds %d", maxFormIndex)
+		}
+		if field.Len() <= index {
+			grown := reflect.MakeSlice(field.Type(), index+1, index+1)
+			reflect.Copy(grown, field)
+			field.Set(grown)
+		}
+		field = field.Index(index)
+	}
+
+	if len(segments) > 1 {
+		return setFormValue(field, segments[1:], vals)
+	}
+
+	field = allocElem(field)
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := setScalar(allocElem(slice.Index(i)), val); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setScalar(field, vals[0])
+}
+
+// allocElem dereferences pointers, allocating nil ones
+func allocElem(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// formField finds the struct field for a form name by `form` tag or Go name
+func formField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if formFieldName(field) == name || strings.EqualFold(field.Name, name) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// formFieldName returns the form name of a field: its form tag, JSON name or Go name
+func formFieldName(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("form"), ",")[0]; tag != "" {
+		return tag
+	}
+	if name, ok := jsonFieldName(field); ok {
+		return name
+	}
+	return field.Name
+}
+
+// hasFormTags reports whether a request type, or a struct nested in it,
+// names fields with form tags, so it is meant to be posted as a form too
+func hasFormTags(t reflect.Type) bool {
+	return formTagged(t, map[reflect.Type]bool{})
+}
+
+func formTagged(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tag := field.Tag.Get("form"); tag != "" && tag != "-" {
+			return true
+		}
+		if formTagged(field.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// formSchemaRef documents a request type as form fields, flattening nested
+// structs into dotted names and lists of structs into indexed names
+func (app *App) formSchemaRef(t reflect.Type) *openapi3.SchemaRef {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return app.schemaRef(t)
+	}
+	schema := &openapi3.Schema{Type: "object", Properties: openapi3.Schemas{}}
+	app.addFormProperties(schema, t, "", map[reflect.Type]bool{})
+	return &openapi3.SchemaRef{Value: schema}
+}
+
+func (app *App) addFormProperties(schema *openapi3.Schema, t reflect.Type, prefix string, seen map[reflect.Type]bool) {
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("form") == "-" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		name := prefix + formFieldName(field)
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		elemType := fieldType
+		if fieldType.Kind() == reflect.Slice {
+			elemType = fieldType.Elem()
+			for elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+		}
+
+		isNested := elemType.Kind() == reflect.Struct && elemType.String() != "time.Time" && elemType != fileHeaderType && !seen[elemType]
+		switch {
+		case isNested && fieldType.Kind() == reflect.Slice:
+			app.addFormProperties(schema, elemType, name+"[0].", seen)
+		case isNested:
+			app.addFormProperties(schema, elemType, name+".", seen)
+		default:
+			schema.Properties[name] = app.schemaRef(field.Type)
+			if hasValidateTag(field, "required") && prefix == "" {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+}
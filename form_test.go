@@ -0,0 +1,135 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type SignupAddress struct {
+	City string `form:"city" json:"city"`
+	Zip  string `form:"zip" json:"zip"`
+}
+
+type SignupUser struct {
+	Name    string        `form:"name" json:"name" validate:"required"`
+	Age     int           `form:"age" json:"age"`
+	Address SignupAddress `form:"address" json:"address"`
+}
+
+type LineItem struct {
+	SKU string `form:"sku" json:"sku"`
+	Qty int    `form:"qty" json:"qty"`
+}
+
+type SignupForm struct {
+	Plan  string     `form:"plan" json:"plan"`
+	User  SignupUser `form:"user" json:"user"`
+	Tags  []string   `form:"tags" json:"tags"`
+	Items []LineItem `form:"items" json:"items"`
+}
+
+func TestNestedFormBinding(t *testing.T) {
+	app := echonext.New()
+	var received SignupForm
+	app.POST("/signup", func(c echo.Context, req SignupForm) (SignupForm, error) {
+		received = req
+		return req, nil
+	}, echonext.Route{ContentTypes: []string{echo.MIMEApplicationForm}})
+
+	form := url.Values{
+		"plan":              {"pro"},
+		"user.name":         {"John"},
+		"user.age":          {"30"},
+		"user.address.city": {"Lagos"},
+		"tags[1]":           {"b"},
+		"tags[0]":           {"a"},
+		"items[0].sku":      {"A-1"},
+		"items[0].qty":      {"2"},
+		"items[1].sku":      {"B-2"},
+	}
+
+	serve := func(form url.Values) int {
+		req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(form))
+	assert.Equal(t, SignupForm{
+		Plan: "pro",
+		User: SignupUser{Name: "John", Age: 30, Address: SignupAddress{City: "Lagos"}},
+		Tags: []string{"a", "b"},
+		Items: []LineItem{
+			{SKU: "A-1", Qty: 2},
+			{SKU: "B-2"},
+		},
+	}, received)
+
+	t.Run("invalid nested value", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(url.Values{"user.name": {"John"}, "user.age": {"old"}}))
+	})
+
+	t.Run("index limit", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(url.Values{"user.name": {"John"}, "tags[5000]": {"x"}}))
+	})
+
+	t.Run("documented as form fields", func(t *testing.T) {
+		schema := app.GenerateOpenAPISpec().Paths["/signup"].Post.RequestBody.Value.Content[echo.MIMEApplicationForm].Schema.Value
+		for _, name := range []string{"plan", "user.name", "user.age", "user.address.city", "tags", "items[0].sku"} {
+			assert.Contains(t, schema.Properties, name)
+		}
+		assert.NotContains(t, schema.Properties, "user")
+	})
+}
+
+func TestFormTaggedRequests(t *testing.T) {
+	app := echonext.New()
+	var received SignupUser
+	app.POST("/users", func(c echo.Context, req SignupUser) (SignupUser, error) {
+		received = req
+		return req, nil
+	})
+	app.POST("/plain", func(c echo.Context, req CreateUserRequest) (TestUser, error) { return TestUser{}, nil })
+
+	post := func(contentType, body string) int {
+		received = SignupUser{}
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("form body", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(echo.MIMEApplicationForm, "name=Ann&age=41&address.city=Lagos"))
+		assert.Equal(t, SignupUser{Name: "Ann", Age: 41, Address: SignupAddress{City: "Lagos"}}, received)
+	})
+
+	t.Run("form body is validated", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post(echo.MIMEApplicationForm, "age=41"))
+	})
+
+	t.Run("JSON body", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(echo.MIMEApplicationJSON, `{"name":"Ann","address":{"city":"Lagos"}}`))
+		assert.Equal(t, SignupUser{Name: "Ann", Address: SignupAddress{City: "Lagos"}}, received)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		content := spec.Paths["/users"].Post.RequestBody.Value.Content
+		assert.Contains(t, content, echo.MIMEApplicationJSON)
+		if assert.Contains(t, content, echo.MIMEApplicationForm) {
+			assert.Contains(t, content[echo.MIMEApplicationForm].Schema.Value.Properties, "address.city")
+		}
+		assert.NotContains(t, spec.Paths["/plain"].Post.RequestBody.Value.Content, echo.MIMEApplicationForm)
+	})
+}
@@ -0,0 +1,116 @@
+package echonext
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UpdateGoldenEnv names the environment variable that makes AssertSpecMatches
+// rewrite golden files instead of comparing against them
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// goldenContext is the number of unchanged lines shown around a difference
+const goldenContext = 3
+
+// TestingT is the subset of *testing.T used by the test helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertSpecMatches generates app's OpenAPI spec and compares it against the
+// JSON in goldenPath, failing t with a line diff when they differ. Run the
+// tests with UPDATE_GOLDEN=1 to write the current spec to goldenPath.
+func AssertSpecMatches(t TestingT, app *App, goldenPath string) bool {
+	t.Helper()
+
+	data, err := app.MarshalOpenAPISpec()
+	if err != nil {
+		t.Errorf("echonext: encoding spec: %v", err)
+		return false
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		t.Errorf("echonext: encoding spec: %v", err)
+		return false
+	}
+	got := append(indented.Bytes(), '\n')
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+			t.Errorf("echonext: updating golden spec: %v", err)
+			return false
+		}
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Errorf("echonext: updating golden spec: %v", err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Errorf("echonext: reading golden spec: %v (run with %s=1 to create it)", err, UpdateGoldenEnv)
+		return false
+	}
+
+	// Compare normalized JSON so formatting of the golden file doesn't matter
+	var normalized bytes.Buffer
+	if err := json.Indent(&normalized, bytes.TrimSpace(want), "", "  "); err != nil {
+		t.Errorf("echonext: golden spec %s is not valid JSON: %v", goldenPath, err)
+		return false
+	}
+	normalized.WriteByte('\n')
+
+	if bytes.Equal(normalized.Bytes(), got) {
+		return true
+	}
+	t.Errorf("echonext: spec does not match %s (run with %s=1 to update):\n%s",
+		goldenPath, UpdateGoldenEnv, lineDiff(normalized.String(), string(got)))
+	return false
+}
+
+// lineDiff describes how got differs from want, showing the changed block of
+// lines between their common prefix and suffix with some surrounding context
+func lineDiff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	start := prefix - goldenContext
+	if start < 0 {
+		start = 0
+	}
+	end := suffix - goldenContext
+	if end < 0 {
+		end = 0
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "@@ line %d @@\n", start+1)
+	for _, line := range a[start:prefix] {
+		out.WriteString("  " + line + "\n")
+	}
+	for _, line := range a[prefix : len(a)-suffix] {
+		out.WriteString("- " + line + "\n")
+	}
+	for _, line := range b[prefix : len(b)-suffix] {
+		out.WriteString("+ " + line + "\n")
+	}
+	for _, line := range a[len(a)-suffix : len(a)-end] {
+		out.WriteString("  " + line + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,67 @@
+package echonext_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT captures failures so failing comparisons can be asserted on
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSpecMatches(t *testing.T) {
+	newApp := func(summary string) *echonext.App {
+		app := echonext.New()
+		app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+			return nil, nil
+		}, echonext.Route{Summary: summary})
+		return app
+	}
+
+	golden := filepath.Join(t.TempDir(), "testdata", "openapi.golden.json")
+
+	t.Run("update", func(t *testing.T) {
+		t.Setenv(echonext.UpdateGoldenEnv, "1")
+		assert.True(t, echonext.AssertSpecMatches(t, newApp("List users"), golden))
+		assert.FileExists(t, golden)
+	})
+
+	t.Run("match", func(t *testing.T) {
+		rt := &recordingT{}
+		assert.True(t, echonext.AssertSpecMatches(rt, newApp("List users"), golden))
+		assert.Empty(t, rt.errors)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		rt := &recordingT{}
+		assert.False(t, echonext.AssertSpecMatches(rt, newApp("List all users"), golden))
+		if assert.Len(t, rt.errors, 1) {
+			assert.Regexp(t, `(?m)^-\s+"summary": "List users"$`, rt.errors[0])
+			assert.Regexp(t, `(?m)^\+\s+"summary": "List all users"$`, rt.errors[0])
+			assert.Contains(t, rt.errors[0], echonext.UpdateGoldenEnv)
+		}
+	})
+
+	t.Run("missing golden", func(t *testing.T) {
+		rt := &recordingT{}
+		assert.False(t, echonext.AssertSpecMatches(rt, newApp("List users"), filepath.Join(t.TempDir(), "missing.json")))
+		assert.Len(t, rt.errors, 1)
+	})
+
+	data, err := os.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"/users"`)
+}
@@ -0,0 +1,103 @@
+package echonext
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Group registers typed routes under a shared path prefix, with default tags,
+// security and middleware
+type Group struct {
+	app        *App
+	prefix     string
+	tags       []string
+	security   []Security
+	middleware []echo.MiddlewareFunc
+}
+
+// Group creates a route group for prefix. Tags and Security in opts apply to
+// every route in the group: tags are added to each route's own, and routes
+// without Security inherit the group's.
+func (app *App) Group(prefix string, opts ...Route) *Group {
+	g := &Group{app: app, prefix: strings.TrimSuffix(prefix, "/")}
+	if len(opts) > 0 {
+		g.tags = opts[0].Tags
+		g.security = opts[0].Security
+	}
+	return g
+}
+
+// Group creates a nested group that inherits this group's prefix, tags,
+// security and middleware
+func (g *Group) Group(prefix string, opts ...Route) *Group {
+	child := g.app.Group(g.prefix+prefix, opts...)
+	child.tags = mergeTags(g.tags, child.tags)
+	if len(child.security) == 0 {
+		child.security = g.security
+	}
+	child.middleware = append(append([]echo.MiddlewareFunc(nil), g.middleware...), child.middleware...)
+	return child
+}
+
+// Use adds middleware run before every route registered on the group afterwards
+func (g *Group) Use(middleware ...echo.MiddlewareFunc) {
+	g.middleware = append(g.middleware, middleware...)
+}
+
+// GET registers a typed GET endpoint under the group's prefix
+func (g *Group) GET(path string, handler interface{}, opts ...Route) {
+	g.register("GET", path, handler, opts...)
+}
+
+// POST registers a typed POST endpoint under the group's prefix
+func (g *Group) POST(path string, handler interface{}, opts ...Route) {
+	g.register("POST", path, handler, opts...)
+}
+
+// PUT registers a typed PUT endpoint under the group's prefix
+func (g *Group) PUT(path string, handler interface{}, opts ...Route) {
+	g.register("PUT", path, handler, opts...)
+}
+
+// PATCH registers a typed PATCH endpoint under the group's prefix
+func (g *Group) PATCH(path string, handler interface{}, opts ...Route) {
+	g.register("PATCH", path, handler, opts...)
+}
+
+// DELETE registers a typed DELETE endpoint under the group's prefix
+func (g *Group) DELETE(path string, handler interface{}, opts ...Route) {
+	g.register("DELETE", path, handler, opts...)
+}
+
+// register merges the group defaults into the route and registers it on the app
+func (g *Group) register(method, path string, handler interface{}, opts ...Route) {
+	if len(opts) > 0 || len(g.tags) > 0 || len(g.security) > 0 {
+		var route Route
+		if len(opts) > 0 {
+			route = opts[0]
+		}
+		route.Tags = mergeTags(g.tags, route.Tags)
+		if len(route.Security) == 0 {
+			route.Security = g.security
+		}
+		opts = []Route{route}
+	}
+	g.app.registerRoute(method, g.prefix+path, handler, g.middleware, opts...)
+}
+
+// mergeTags returns the group tags followed by the route's own, without duplicates
+func mergeTags(group, route []string) []string {
+	if len(group) == 0 {
+		return route
+	}
+	merged := make([]string, 0, len(group)+len(route))
+	seen := make(map[string]bool, len(group)+len(route))
+	for _, tag := range append(append([]string(nil), group...), route...) {
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
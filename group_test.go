@@ -0,0 +1,74 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteGroups(t *testing.T) {
+	app := echonext.New()
+	app.AddSecurityScheme("bearerAuth", echonext.Security{Type: "bearer", Scheme: "bearer"})
+
+	v1 := app.Group("/v1", echonext.Route{
+		Tags:     []string{"v1"},
+		Security: []echonext.Security{{Type: "bearer"}},
+	})
+
+	var calls []string
+	v1.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			calls = append(calls, "v1")
+			if c.Request().Header.Get("Authorization") == "" {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+			return next(c)
+		}
+	})
+
+	v1.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		calls = append(calls, "handler")
+		return []TestUser{}, nil
+	}, echonext.Route{Tags: []string{"Users"}})
+
+	admin := v1.Group("/admin", echonext.Route{Tags: []string{"Admin"}})
+	admin.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		return TestUser{}, nil
+	}, echonext.Route{Security: []echonext.Security{{Type: "apiKey", Name: "X-Admin-Key", In: "header"}}})
+
+	t.Run("served under prefix", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/users", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"v1", "handler"}, calls)
+
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("middleware runs before binding", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/admin/users", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+
+		list := spec.Paths["/v1/users"].Get
+		assert.Equal(t, []string{"v1", "Users"}, list.Tags)
+		assert.Contains(t, (*list.Security)[0], "bearerAuth")
+
+		create := spec.Paths["/v1/admin/users"].Post
+		assert.Equal(t, []string{"v1", "Admin"}, create.Tags)
+		assert.Len(t, *create.Security, 1)
+		assert.NotContains(t, (*create.Security)[0], "bearerAuth")
+	})
+}
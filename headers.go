@@ -0,0 +1,111 @@
+package echonext
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// headerField is a request struct field bound from a header
+type headerField struct {
+	name     string
+	field    reflect.StructField
+	required bool
+}
+
+// headerFields returns the fields of t tagged `header:"..."`
+func headerFields(t reflect.Type) []headerField {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []headerField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("header")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, headerField{
+			name:     name,
+			field:    field,
+			required: hasValidateTag(field, "required"),
+		})
+	}
+	return fields
+}
+
+// hasValidateTag reports whether a field's validate tag includes tag
+func hasValidateTag(field reflect.StructField, tag string) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// missingHeaders reports required header fields absent from the request
+func missingHeaders(c echo.Context, fields []headerField) []FieldError {
+	var details []FieldError
+	for _, f := range fields {
+		if f.required && c.Request().Header.Get(f.name) == "" {
+			details = append(details, FieldError{
+				Field:   f.name,
+				In:      "header",
+				Tag:     "required",
+				Message: fmt.Sprintf("%s header is required", f.name),
+			})
+		}
+	}
+	return details
+}
+
+// headerValues collects the request's values for header fields, keyed by the
+// names in their tags rather than canonical header keys
+func headerValues(c echo.Context, fields []headerField) url.Values {
+	values := url.Values{}
+	for _, f := range fields {
+		if vals := c.Request().Header.Values(f.name); len(vals) > 0 {
+			values[f.name] = vals
+		}
+	}
+	return values
+}
+
+// addHeaderParameters documents header fields of a request struct, skipping
+// headers already described by Route.RequestHeaders
+func (app *App) addHeaderParameters(operation *openapi3.Operation, t reflect.Type, declared map[string]HeaderInfo) {
+	for _, f := range headerFields(t) {
+		if isDeclaredHeader(declared, f.name) {
+			continue
+		}
+		param := &openapi3.Parameter{
+			Name:     f.name,
+			In:       "header",
+			Required: f.required,
+			Schema:   app.schemaRef(f.field.Type),
+		}
+		operation.Parameters = append(operation.Parameters, &openapi3.ParameterRef{Value: param})
+	}
+}
+
+// isDeclaredHeader reports whether name is in declared, ignoring case
+func isDeclaredHeader(declared map[string]HeaderInfo, name string) bool {
+	for header := range declared {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+	return false
+}
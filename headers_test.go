@@ -0,0 +1,105 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type CreateProjectRequest struct {
+	TenantID string `json:"-" header:"X-Tenant-ID" validate:"required"`
+	TraceTag string `json:"-" header:"X-Trace-Tag"`
+	Name     string `json:"name" validate:"required"`
+}
+
+type Project struct {
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name"`
+}
+
+func TestRequiredHeaderFields(t *testing.T) {
+	app := echonext.New()
+	app.POST("/projects", func(c echo.Context, req CreateProjectRequest) (Project, error) {
+		return Project{TenantID: req.TenantID, Name: req.Name}, nil
+	})
+
+	serve := func(tenant string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/projects", bytes.NewReader([]byte(`{"name":"Apollo"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if tenant != "" {
+			req.Header.Set("x-tenant-id", tenant)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("bound", func(t *testing.T) {
+		rec := serve("acme")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response echonext.Response[Project]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "acme", response.Data.TenantID)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		rec := serve("")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "Missing required headers: X-Tenant-ID header is required", response.Error)
+		assert.Equal(t, []echonext.FieldError{{
+			Field: "X-Tenant-ID", In: "header", Tag: "required", Message: "X-Tenant-ID header is required",
+		}}, response.Details)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		operation := app.GenerateOpenAPISpec().Paths["/projects"].Post
+
+		params := map[string]bool{}
+		for _, p := range operation.Parameters {
+			if p.Value.In == "header" {
+				params[p.Value.Name] = p.Value.Required
+			}
+		}
+		assert.Equal(t, map[string]bool{"X-Tenant-ID": true, "X-Trace-Tag": false}, params)
+
+		body := operation.RequestBody.Value.Content["application/json"].Schema.Value
+		assert.NotContains(t, body.Properties, "TenantID")
+	})
+}
+
+func TestHeaderTypeErrors(t *testing.T) {
+	type ListRequest struct {
+		PageSize int    `header:"X-Page-Size"`
+		Cursor   string `header:"X-Cursor"`
+	}
+
+	app := echonext.New()
+	app.GET("/projects", func(c echo.Context, req ListRequest) ([]Project, error) {
+		return []Project{{Name: req.Cursor}}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set("X-Page-Size", "lots")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, `Invalid headers: X-Page-Size must be an integer, got "lots"`, response.Error)
+	assert.Equal(t, []echonext.FieldError{{
+		Field:    "X-Page-Size",
+		In:       "header",
+		Expected: "integer",
+		Value:    "lots",
+		Message:  `X-Page-Size must be an integer, got "lots"`,
+	}}, response.Details)
+}
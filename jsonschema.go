@@ -0,0 +1,213 @@
+package echonext
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defsPrefix is the JSON pointer prefix for definitions of standalone schemas
+const defsPrefix = "#/$defs/"
+
+// SchemaFor returns a self-contained schema for t, as documented in the spec
+// but with component references inlined. Recursive types can't be inlined
+// fully; they are kept under "$defs" and referenced from there.
+func (app *App) SchemaFor(t reflect.Type) (*openapi3.Schema, error) {
+	if t == nil {
+		return nil, errors.New("echonext: SchemaFor needs a type")
+	}
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	defer app.isolateSchemas()()
+	return app.standaloneSchema(t)
+}
+
+// ExportSchemas writes a self-contained schema for each request and response
+// type of the documented routes to dir, named after the type, e.g.
+// CreateTodoRequest.json. Types from different packages that share a name
+// are qualified by package.
+func (app *App) ExportSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	defer app.isolateSchemas()()
+
+	taken := map[string]bool{}
+	for _, t := range app.exportedTypes() {
+		schema, err := app.standaloneSchema(t)
+		if err != nil {
+			return fmt.Errorf("echonext: schema for %s: %w", t, err)
+		}
+
+		name := cleanTypeName(t.Name())
+		if taken[name] {
+			pkg := t.PkgPath()
+			pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+			name = uniqueID(exportedName(cleanIdentifier(pkg))+name, taken)
+		}
+		taken[name] = true
+		if schema.Title == "" {
+			schema.Title = name
+		}
+
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("echonext: schema for %s: %w", t, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportedTypes lists the named struct types that documented routes take or
+// return, in registration order. Slices, pointers and channels are unwrapped
+// to their elements.
+func (app *App) exportedTypes() []reflect.Type {
+	var types []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for _, route := range app.routes {
+		if !route.isEnabled() && !app.documentDisabled {
+			continue
+		}
+		for _, t := range []reflect.Type{route.RequestType, route.ResponseType} {
+			for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Chan) {
+				t = t.Elem()
+			}
+			if t == nil || t.Kind() != reflect.Struct || t.Name() == "" || seen[t] {
+				continue
+			}
+			switch t {
+			case fileResponseType, redirectType, eventType, timeType:
+				continue
+			}
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// isolateSchemas generates schemas into fresh components and caches, so
+// standalone schemas don't add components to the spec. It returns a function
+// restoring the spec's. Callers hold specMu.
+func (app *App) isolateSchemas() func() {
+	components, cache, names := app.spec.Components.Schemas, app.schemaCache, app.componentNames
+	app.spec.Components.Schemas, app.schemaCache, app.componentNames = openapi3.Schemas{}, nil, nil
+	return func() {
+		app.spec.Components.Schemas, app.schemaCache, app.componentNames = components, cache, names
+	}
+}
+
+// standaloneSchema generates the schema for t and inlines the components it
+// references. Callers hold specMu and have isolated schemas.
+func (app *App) standaloneSchema(t reflect.Type) (*openapi3.Schema, error) {
+	ref := app.schemaRef(t)
+	if err := ref.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	d := &dereferencer{
+		components: app.spec.Components.Schemas,
+		defs:       openapi3.Schemas{},
+		active:     map[string]bool{},
+	}
+	schema := d.deref(ref).Value
+
+	// Definitions may refer to further recursive components
+	for pending := true; pending; {
+		pending = false
+		for name, def := range d.defs {
+			if def == nil {
+				pending = true
+				d.active[name] = true
+				d.defs[name] = &openapi3.SchemaRef{Value: d.schema(d.components[name].Value)}
+				delete(d.active, name)
+			}
+		}
+	}
+	if len(d.defs) > 0 {
+		extensions := map[string]interface{}{"$defs": d.defs}
+		for key, value := range schema.Extensions {
+			extensions[key] = value
+		}
+		schema.Extensions = extensions
+	}
+	return schema, nil
+}
+
+// dereferencer copies schemas, replacing component references with the
+// components they refer to
+type dereferencer struct {
+	components openapi3.Schemas
+	defs       openapi3.Schemas // Recursive components; nil until copied
+	active     map[string]bool  // Components being copied
+}
+
+func (d *dereferencer) deref(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+	if ref == nil {
+		return nil
+	}
+	if !strings.HasPrefix(ref.Ref, componentSchemaPrefix) {
+		if ref.Value == nil {
+			return ref
+		}
+		return &openapi3.SchemaRef{Value: d.schema(ref.Value)}
+	}
+
+	name := strings.TrimPrefix(ref.Ref, componentSchemaPrefix)
+	if d.active[name] {
+		if _, ok := d.defs[name]; !ok {
+			d.defs[name] = nil
+		}
+		return &openapi3.SchemaRef{Ref: defsPrefix + name}
+	}
+	component := d.components[name]
+	if component == nil {
+		return &openapi3.SchemaRef{Value: d.schema(ref.Value)}
+	}
+	d.active[name] = true
+	defer delete(d.active, name)
+	return &openapi3.SchemaRef{Value: d.schema(component.Value)}
+}
+
+func (d *dereferencer) schema(schema *openapi3.Schema) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	clone := *schema
+	if schema.Properties != nil {
+		clone.Properties = make(openapi3.Schemas, len(schema.Properties))
+		for name, property := range schema.Properties {
+			clone.Properties[name] = d.deref(property)
+		}
+	}
+	clone.Items = d.deref(schema.Items)
+	clone.Not = d.deref(schema.Not)
+	clone.AdditionalProperties.Schema = d.deref(schema.AdditionalProperties.Schema)
+	clone.AllOf = d.refs(schema.AllOf)
+	clone.AnyOf = d.refs(schema.AnyOf)
+	clone.OneOf = d.refs(schema.OneOf)
+	return &clone
+}
+
+func (d *dereferencer) refs(refs openapi3.SchemaRefs) openapi3.SchemaRefs {
+	if refs == nil {
+		return nil
+	}
+	copied := make(openapi3.SchemaRefs, len(refs))
+	for i, ref := range refs {
+		copied[i] = d.deref(ref)
+	}
+	return copied
+}
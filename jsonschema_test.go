@@ -0,0 +1,80 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaFor(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+
+	schema, err := app.SchemaFor(reflect.TypeOf(Link{}))
+	require.NoError(t, err)
+	assert.Equal(t, "object", schema.Type)
+	assert.Empty(t, schema.Properties["local"].Ref)
+	assert.Contains(t, schema.Properties["local"].Value.Properties, "href")
+	assert.Contains(t, schema.Properties["owner"].Value.AllOf[0].Value.Properties, "name")
+
+	// Standalone schemas don't leak components into the spec
+	spec := app.GenerateOpenAPISpec()
+	assert.Contains(t, spec.Components.Schemas, "TestUser")
+	assert.NotContains(t, spec.Components.Schemas, "Link")
+	assert.NotContains(t, spec.Components.Schemas, "URL")
+
+	t.Run("recursive types use $defs", func(t *testing.T) {
+		schema, err := app.SchemaFor(reflect.TypeOf(Category{}))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(schema)
+		require.NoError(t, err)
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &doc))
+
+		properties := doc["properties"].(map[string]interface{})
+		children := properties["children"].(map[string]interface{})
+		assert.Equal(t, "#/$defs/Category", children["items"].(map[string]interface{})["$ref"])
+		assert.Contains(t, doc["$defs"], "Category")
+	})
+
+	t.Run("nil type", func(t *testing.T) {
+		_, err := app.SchemaFor(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestExportSchemas(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (*TestUser, error) { return &TestUser{}, nil })
+	app.GET("/tasks", func(c echo.Context) (Page[Task], error) { return Page[Task]{}, nil })
+	app.GET("/links", func(c echo.Context) (Link, error) { return Link{}, nil })
+
+	dir := filepath.Join(t.TempDir(), "schemas")
+	require.NoError(t, app.ExportSchemas(dir))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"TestUser.json", "CreateUserRequest.json", "PageTask.json", "Link.json"}, names)
+
+	data, err := os.ReadFile(filepath.Join(dir, "PageTask.json"))
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "PageTask", doc["title"])
+	assert.NotContains(t, string(data), "#/components/schemas/")
+	items := doc["properties"].(map[string]interface{})["items"].(map[string]interface{})
+	assert.Contains(t, items["items"].(map[string]interface{})["properties"], "title")
+}
@@ -0,0 +1,172 @@
+package echonext
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MetricsPath is where EnableMetrics serves metrics
+const MetricsPath = "/metrics"
+
+// durationBuckets are the upper bounds, in seconds, of the latency histogram
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metricsRegistry holds the metrics of every served typed route, keyed by
+// method and OpenAPI path template so raw URLs don't multiply series
+type metricsRegistry struct {
+	mu     sync.Mutex
+	routes map[string]*routeMetrics
+}
+
+// routeMetrics counts the requests to one route
+type routeMetrics struct {
+	method, path string
+	inFlight     atomic.Int64
+
+	mu       sync.Mutex
+	statuses map[int]uint64 // Requests by response status
+	buckets  []uint64       // Requests by latency bucket, not cumulative
+	sum      float64        // Total latency in seconds
+	count    uint64
+}
+
+// route returns the metrics for a route, creating them on first use
+func (r *metricsRegistry) route(method, path string) *routeMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := method + " " + path
+	if m, ok := r.routes[key]; ok {
+		return m
+	}
+	if r.routes == nil {
+		r.routes = make(map[string]*routeMetrics)
+	}
+	m := &routeMetrics{
+		method:   method,
+		path:     path,
+		statuses: make(map[int]uint64),
+		buckets:  make([]uint64, len(durationBuckets)+1),
+	}
+	r.routes[key] = m
+	return m
+}
+
+func (m *routeMetrics) observe(status int, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	bucket := sort.SearchFloat64s(durationBuckets, seconds)
+
+	m.mu.Lock()
+	m.statuses[status]++
+	m.buckets[bucket]++
+	m.sum += seconds
+	m.count++
+	m.mu.Unlock()
+}
+
+// EnableMetrics records the request count, latency and in-flight requests of
+// every typed route, labeled by method and path template, and serves them at
+// /metrics in the Prometheus text format. Counts are labeled by the status
+// the client received. Call it before the server starts.
+func (app *App) EnableMetrics() {
+	if app.metricsEnabled {
+		return
+	}
+	app.metricsEnabled = true
+	app.Echo.GET(MetricsPath, func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+		return c.String(http.StatusOK, app.metrics.String())
+	})
+}
+
+// measure records the metrics of a route's requests once metrics are enabled
+func (app *App) measure(m *routeMetrics, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !app.metricsEnabled {
+			return next(c)
+		}
+		m.inFlight.Add(1)
+		defer m.inFlight.Add(-1)
+		start := time.Now()
+
+		err := next(c)
+		status := c.Response().Status
+		if err != nil && !c.Response().Committed {
+			// Echo's error handler writes the response after we return
+			status = http.StatusInternalServerError
+			if he, ok := err.(*echo.HTTPError); ok {
+				status = he.Code
+			}
+		}
+		m.observe(status, time.Since(start))
+		return err
+	}
+}
+
+// String renders the metrics in the Prometheus text exposition format
+func (r *metricsRegistry) String() string {
+	r.mu.Lock()
+	routes := make([]*routeMetrics, 0, len(r.routes))
+	for _, m := range r.routes {
+		routes = append(routes, m)
+	}
+	r.mu.Unlock()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return routes[i].method < routes[j].method
+	})
+
+	var b strings.Builder
+	b.WriteString("# HELP echonext_http_requests_total Requests handled by typed routes.\n")
+	b.WriteString("# TYPE echonext_http_requests_total counter\n")
+	for _, m := range routes {
+		m.mu.Lock()
+		statuses := make([]int, 0, len(m.statuses))
+		for status := range m.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "echonext_http_requests_total{%s,status=\"%d\"} %d\n", m.labels(), status, m.statuses[status])
+		}
+		m.mu.Unlock()
+	}
+
+	b.WriteString("# HELP echonext_http_request_duration_seconds Latency of requests handled by typed routes.\n")
+	b.WriteString("# TYPE echonext_http_request_duration_seconds histogram\n")
+	for _, m := range routes {
+		m.mu.Lock()
+		var cumulative uint64
+		for i, bound := range durationBuckets {
+			cumulative += m.buckets[i]
+			fmt.Fprintf(&b, "echonext_http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", m.labels(), strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "echonext_http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", m.labels(), m.count)
+		fmt.Fprintf(&b, "echonext_http_request_duration_seconds_sum{%s} %s\n", m.labels(), strconv.FormatFloat(m.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "echonext_http_request_duration_seconds_count{%s} %d\n", m.labels(), m.count)
+		m.mu.Unlock()
+	}
+
+	b.WriteString("# HELP echonext_http_requests_in_flight Requests being handled by typed routes.\n")
+	b.WriteString("# TYPE echonext_http_requests_in_flight gauge\n")
+	for _, m := range routes {
+		fmt.Fprintf(&b, "echonext_http_requests_in_flight{%s} %d\n", m.labels(), m.inFlight.Load())
+	}
+	return b.String()
+}
+
+// labelEscaper escapes label values as the text format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (m *routeMetrics) labels() string {
+	return `method="` + labelEscaper.Replace(m.method) + `",path="` + labelEscaper.Replace(m.path) + `"`
+}
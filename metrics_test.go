@@ -0,0 +1,47 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		if c.Param("id") == "missing" {
+			return TestUser{}, echo.NewHTTPError(http.StatusNotFound, "user not found")
+		}
+		return TestUser{ID: c.Param("id")}, nil
+	})
+	app.EnableMetrics()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	get("/users/1")
+	get("/users/2")
+	get("/users/missing")
+
+	rec := get(echonext.MetricsPath)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/plain; version=0.0.4")
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "# TYPE echonext_http_requests_total counter\n")
+	assert.Contains(t, body, `echonext_http_requests_total{method="GET",path="/users/{id}",status="200"} 2`)
+	assert.Contains(t, body, `echonext_http_requests_total{method="GET",path="/users/{id}",status="404"} 1`)
+	assert.Contains(t, body, `echonext_http_request_duration_seconds_bucket{method="GET",path="/users/{id}",le="+Inf"} 3`)
+	assert.Contains(t, body, `echonext_http_request_duration_seconds_count{method="GET",path="/users/{id}"} 3`)
+	assert.Contains(t, body, `echonext_http_requests_in_flight{method="GET",path="/users/{id}"} 0`)
+	assert.NotContains(t, body, "/users/1", "series use the path template")
+	assert.NotContains(t, body, "/metrics")
+
+	assert.NotContains(t, app.GenerateOpenAPISpec().Paths, echonext.MetricsPath)
+}
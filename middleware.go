@@ -0,0 +1,46 @@
+package echonext
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MiddlewareFunc inspects a request before the handler runs. Returning handled
+// stops the chain and sends body with status through the response envelope;
+// a non-nil err is sent as an error envelope.
+type MiddlewareFunc func(c echo.Context) (handled bool, status int, body interface{}, err error)
+
+// Middleware adapts a typed middleware to Echo, so auth or caching layers can
+// short-circuit with responses shaped like those of typed handlers
+func Middleware(fn MiddlewareFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			handled, status, body, err := fn(c)
+			if err != nil {
+				if status < http.StatusBadRequest {
+					status = http.StatusInternalServerError
+				}
+				return errorResponseFor(c, err, status)
+			}
+			if !handled {
+				return next(c)
+			}
+
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusBadRequest {
+				message, ok := body.(string)
+				if !ok {
+					message = http.StatusText(status)
+				}
+				return errorResponse(c, status, message)
+			}
+			if body == nil {
+				return c.NoContent(status)
+			}
+			return respond(c, status, envelopeFor(c).Success(c, body))
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package echonext_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedMiddleware(t *testing.T) {
+	app := echonext.New()
+	cache := map[string]TestUser{"/users/cached": {ID: "cached"}}
+
+	app.Use(echonext.Middleware(func(c echo.Context) (bool, int, interface{}, error) {
+		if c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+			return true, http.StatusUnauthorized, "Missing credentials", nil
+		}
+		return false, 0, nil, nil
+	}))
+	app.Use(echonext.Middleware(func(c echo.Context) (bool, int, interface{}, error) {
+		if user, ok := cache[c.Request().URL.Path]; ok {
+			return true, http.StatusOK, user, nil
+		}
+		return false, 0, nil, nil
+	}))
+
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		return TestUser{ID: c.Param("id")}, nil
+	})
+
+	serve := func(path string, authorized bool) (int, echonext.Response[TestUser]) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if authorized {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		var response echonext.Response[TestUser]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return rec.Code, response
+	}
+
+	t.Run("short-circuits with enveloped 401", func(t *testing.T) {
+		status, response := serve("/users/1", false)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.False(t, response.Success)
+		assert.Equal(t, "Missing credentials", response.Error)
+	})
+
+	t.Run("short-circuits with data", func(t *testing.T) {
+		status, response := serve("/users/cached", true)
+		assert.Equal(t, http.StatusOK, status)
+		assert.True(t, response.Success)
+		assert.Equal(t, "cached", response.Data.ID)
+	})
+
+	t.Run("passes through", func(t *testing.T) {
+		status, response := serve("/users/1", true)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "1", response.Data.ID)
+	})
+}
+
+func TestRouteMiddleware(t *testing.T) {
+	app := echonext.New()
+
+	var order []string
+	trace := func(name string) echo.MiddlewareFunc {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				order = append(order, name)
+				return next(c)
+			}
+		}
+	}
+	requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get("Authorization") == "" {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+			}
+			return next(c)
+		}
+	}
+
+	app.GET("/todos", func(c echo.Context) ([]TestUser, error) {
+		return []TestUser{}, nil
+	})
+	app.POST("/todos", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		order = append(order, "handler")
+		return TestUser{Name: req.Name}, nil
+	}, echonext.Route{Middleware: []echo.MiddlewareFunc{requireAuth}})
+
+	v1 := app.Group("/v1")
+	v1.Use(trace("group"))
+	v1.POST("/todos", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+		order = append(order, "handler")
+		return TestUser{Name: req.Name}, nil
+	}, echonext.Route{Middleware: []echo.MiddlewareFunc{trace("route")}})
+
+	post := func(path, auth, body string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Invalid bodies are rejected by the middleware before validation runs
+	assert.Equal(t, http.StatusUnauthorized, post("/todos", "", `{}`))
+	assert.Equal(t, http.StatusBadRequest, post("/todos", "Bearer token", `{}`))
+	assert.Equal(t, http.StatusOK, post("/todos", "Bearer token", `{"name":"John","email":"john@example.com"}`))
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	order = nil
+	assert.Equal(t, http.StatusOK, post("/v1/todos", "", `{"name":"John","email":"john@example.com"}`))
+	assert.Equal(t, []string{"group", "route", "handler"}, order)
+}
+
+func TestTypedMiddlewareResponses(t *testing.T) {
+	app := echonext.New()
+
+	cached := echonext.Middleware(func(c echo.Context) (bool, int, any, error) {
+		switch c.Param("id") {
+		case "cached":
+			return true, http.StatusOK, Book{ID: "cached", Title: "Dune"}, nil
+		case "slow":
+			return false, 0, nil, c.Request().Context().Err()
+		}
+		return false, 0, nil, nil
+	})
+	app.GET("/books/:id", func(c echo.Context) (Book, error) {
+		return Book{ID: c.Param("id")}, nil
+	}, echonext.Route{
+		ContentTypes: []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML},
+		Middleware:   []echo.MiddlewareFunc{cached},
+	})
+
+	t.Run("negotiated format", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/books/cached", nil)
+		req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationXML)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response echonext.Response[Book]
+		assert.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "Dune", response.Data.Title)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		req := httptest.NewRequest(http.MethodGet, "/books/slow", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Request timed out")
+	})
+}
@@ -0,0 +1,40 @@
+package echonext
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// MultiStatus is the result of a bulk operation whose items succeed or fail
+// independently. Typed handlers returning it respond with 207 Multi-Status.
+type MultiStatus[T any] struct {
+	Items []ItemResult[T] `json:"items"`
+}
+
+// ItemResult is the outcome of one item of a bulk operation
+type ItemResult[T any] struct {
+	Index  int    `json:"index"`           // Position of the item in the request
+	Status int    `json:"status"`          // HTTP status of this item
+	Data   T      `json:"data,omitempty"`  // Result for successful items
+	Error  string `json:"error,omitempty"` // Reason for failed items
+}
+
+func (MultiStatus[T]) isMultiStatus() {}
+
+var multiStatusType = reflect.TypeOf((*interface{ isMultiStatus() })(nil)).Elem()
+
+// isMultiStatus reports whether t is a MultiStatus instantiation
+func isMultiStatus(t reflect.Type) bool {
+	return t != nil && t.Implements(multiStatusType)
+}
+
+// successStatus returns the status of a successful response for a route
+func successStatus(route *Route, responseType reflect.Type) int {
+	if route != nil && route.SuccessStatus > 0 {
+		return route.SuccessStatus
+	}
+	if isMultiStatus(responseType) {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,70 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type BulkCreateUsersRequest struct {
+	Users []CreateUserRequest `json:"users" validate:"required"`
+}
+
+func TestMultiStatus(t *testing.T) {
+	app := echonext.New()
+	app.POST("/users/bulk", func(c echo.Context, req BulkCreateUsersRequest) (echonext.MultiStatus[TestUser], error) {
+		var result echonext.MultiStatus[TestUser]
+		for i, user := range req.Users {
+			if user.Email == "" {
+				result.Items = append(result.Items, echonext.ItemResult[TestUser]{
+					Index: i, Status: http.StatusBadRequest, Error: "email is required",
+				})
+				continue
+			}
+			result.Items = append(result.Items, echonext.ItemResult[TestUser]{
+				Index: i, Status: http.StatusCreated, Data: TestUser{ID: "1", Name: user.Name, Email: user.Email},
+			})
+		}
+		return result, nil
+	})
+
+	t.Run("mixed results", func(t *testing.T) {
+		body, _ := json.Marshal(BulkCreateUsersRequest{Users: []CreateUserRequest{
+			{Name: "John", Email: "john@example.com"},
+			{Name: "Jane"},
+		}})
+		req := httptest.NewRequest(http.MethodPost, "/users/bulk", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMultiStatus, rec.Code)
+		var response echonext.Response[echonext.MultiStatus[TestUser]]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, []echonext.ItemResult[TestUser]{
+			{Index: 0, Status: http.StatusCreated, Data: TestUser{ID: "1", Name: "John", Email: "john@example.com"}},
+			{Index: 1, Status: http.StatusBadRequest, Error: "email is required"},
+		}, response.Data.Items)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		responses := spec.Paths["/users/bulk"].Post.Responses
+		assert.Nil(t, responses["200"])
+		assert.NotNil(t, responses["207"])
+
+		data := responses["207"].Value.Content["application/json"].Schema.Value.Properties["data"].Value
+		item := data.Properties["items"].Value.Items.Value
+		assert.Contains(t, item.Properties, "index")
+		assert.Contains(t, item.Properties, "status")
+		assert.Contains(t, item.Properties, "error")
+		assert.Contains(t, item.Properties["data"].Value.Properties, "email")
+	})
+}
@@ -0,0 +1,217 @@
+package echonext
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NamingStrategy controls the JSON names of struct fields without a name in
+// their json tag
+type NamingStrategy int
+
+const (
+	// NamingGoField keeps the Go field name, e.g. CreatedAt, as encoding/json
+	// does. This is the default.
+	NamingGoField NamingStrategy = iota
+	// NamingSnakeCase names fields like created_at and user_id
+	NamingSnakeCase
+	// NamingCamelCase names fields like createdAt and userId
+	NamingCamelCase
+)
+
+// SetNamingStrategy names untagged struct fields in JSON bodies, both on the
+// wire and in the spec, so documented properties match the bytes sent.
+// Names in json tags are always kept. Set it before registering routes.
+func (app *App) SetNamingStrategy(strategy NamingStrategy) {
+	app.naming = strategy
+	app.invalidateSchemas()
+}
+
+// fieldName returns the JSON property name of a struct field under the
+// strategy and false when encoding/json skips the field
+func (n NamingStrategy) fieldName(field reflect.StructField) (string, bool) {
+	name, ok := jsonFieldName(field)
+	if !ok || n == NamingGoField || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+		return name, ok
+	}
+	return n.convert(field.Name), true
+}
+
+// convert applies the strategy to a Go identifier
+func (n NamingStrategy) convert(name string) string {
+	words := splitWords(name)
+	switch n {
+	case NamingSnakeCase:
+		for i, word := range words {
+			words[i] = strings.ToLower(word)
+		}
+		return strings.Join(words, "_")
+	case NamingCamelCase:
+		for i, word := range words {
+			word = strings.ToLower(word)
+			if i > 0 {
+				word = exportedName(word)
+			}
+			words[i] = word
+		}
+		return strings.Join(words, "")
+	}
+	return name
+}
+
+// splitWords splits a Go identifier into words, keeping acronyms together:
+// UserID is User and ID, HTTPServer is HTTP and Server
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, r := runes[i-1], runes[i]
+		next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if r == '_' {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next)) {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// encodeNames converts data, of type t, into a JSON value tree with its
+// fields named by the naming strategy
+func (app *App) encodeNames(t reflect.Type, data interface{}) (interface{}, error) {
+	tree, err := jsonTree(data)
+	if err != nil {
+		return nil, err
+	}
+	return app.walkNames(t, tree, true), nil
+}
+
+// decodeNamedBody rewrites a JSON request body so fields named by the naming
+// strategy get the Go names the binder expects. It returns the error reading
+// the body; bodies that fail to parse are left for the binder to report.
+func (app *App) decodeNamedBody(c echo.Context, t reflect.Type) error {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil
+	}
+	rewritten, err := json.Marshal(app.walkNames(t, tree, false))
+	if err != nil {
+		return nil
+	}
+	req.Body = io.NopCloser(bytes.NewReader(rewritten))
+	req.ContentLength = int64(len(rewritten))
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// walkNames walks a decoded JSON tree alongside its Go type, renaming fields
+// from their Go names to the strategy's (toWire) or back. Types that encode
+// themselves are left alone.
+func (app *App) walkNames(t reflect.Type, node interface{}, toWire bool) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ptr := reflect.PtrTo(t)
+	if ptr.Implements(jsonMarshalerType) || ptr.Implements(jsonUnmarshalerType) ||
+		ptr.Implements(textMarshalerType) || ptr.Implements(textUnmarshalerType) {
+		return node
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := node.([]interface{}); ok {
+			for i, item := range items {
+				items[i] = app.walkNames(t.Elem(), item, toWire)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := node.(map[string]interface{}); ok {
+			for k, v := range obj {
+				obj[k] = app.walkNames(t.Elem(), v, toWire)
+			}
+		}
+	case reflect.Struct:
+		if obj, ok := node.(map[string]interface{}); ok {
+			renamed := make(map[string]interface{}, len(obj))
+			app.walkNameFields(t, obj, renamed, toWire)
+			// Keep keys no field claims, such as misspelled ones the binder ignores
+			for k, v := range obj {
+				if _, taken := renamed[k]; !taken {
+					renamed[k] = v
+				}
+			}
+			return renamed
+		}
+	}
+	return node
+}
+
+// walkNameFields moves the fields of t from obj to renamed under their new
+// names, consuming them from obj. Declared fields go first, since they
+// shadow those promoted from embedded structs.
+func (app *App) walkNameFields(t reflect.Type, obj, renamed map[string]interface{}, toWire bool) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		from, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened into the parent
+		if isEmbeddedStruct(field) {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			embedded = append(embedded, fieldType)
+			continue
+		}
+
+		to, _ := app.naming.fieldName(field)
+		if !toWire {
+			from, to = to, from
+		}
+		if v, exists := obj[from]; exists {
+			if _, taken := renamed[to]; !taken {
+				renamed[to] = app.walkNames(field.Type, v, toWire)
+			}
+			delete(obj, from)
+		}
+	}
+	for _, fieldType := range embedded {
+		app.walkNameFields(fieldType, obj, renamed, toWire)
+	}
+}
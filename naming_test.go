@@ -0,0 +1,152 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CustomerOwner struct {
+	FullName string
+}
+
+type Timestamps struct {
+	CreatedAt time.Time
+}
+
+type Customer struct {
+	Timestamps
+	CustomerID  string `json:"id"`
+	DisplayName string `validate:"required"`
+	HTTPPort    int    `json:",omitempty"`
+	Owner       *CustomerOwner
+	Labels      map[string]CustomerOwner
+}
+
+func TestNamingStrategy(t *testing.T) {
+	app := echonext.New()
+	app.SetNamingStrategy(echonext.NamingSnakeCase)
+
+	var received Customer
+	app.POST("/customers", func(c echo.Context, req Customer) (Customer, error) {
+		received = req
+		return req, nil
+	})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		received = Customer{}
+		req := httptest.NewRequest(http.MethodPost, "/customers", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("wire names", func(t *testing.T) {
+		rec := post(`{"id":"a1","display_name":"Ann","http_port":8080,"owner":{"full_name":"Ann Lee"},` +
+			`"labels":{"x":{"full_name":"X"}},"created_at":"2024-01-01T00:00:00Z"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "a1", received.CustomerID)
+		assert.Equal(t, "Ann", received.DisplayName)
+		assert.Equal(t, 8080, received.HTTPPort)
+		assert.Equal(t, "Ann Lee", received.Owner.FullName)
+		assert.Equal(t, "X", received.Labels["x"].FullName)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), received.CreatedAt)
+
+		var response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, map[string]interface{}{
+			"id":           "a1",
+			"display_name": "Ann",
+			"http_port":    float64(8080),
+			"owner":        map[string]interface{}{"full_name": "Ann Lee"},
+			"labels":       map[string]interface{}{"x": map[string]interface{}{"full_name": "X"}},
+			"created_at":   "2024-01-01T00:00:00Z",
+		}, response.Data)
+	})
+
+	t.Run("validation errors use wire names", func(t *testing.T) {
+		rec := post(`{"id":"a1"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var invalid echonext.Response[any]
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &invalid))
+		if assert.Len(t, invalid.Details, 1) {
+			assert.Equal(t, "display_name", invalid.Details[0].Field)
+		}
+	})
+
+	t.Run("type errors use wire names", func(t *testing.T) {
+		for body, field := range map[string]string{
+			`{"display_name":"Ann","http_port":"abc"}`:                          "http_port",
+			`{"display_name":"Ann","http_port":1e30}`:                           "http_port",
+			`{"display_name":"Ann","owner":{"full_name":7}}`:                    "owner.full_name",
+			`{"display_name":"Ann","created_at":"2024-01-01T00:00:00Z","id":1}`: "id",
+		} {
+			rec := post(body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+			assert.NotContains(t, rec.Body.String(), "offset", body)
+			var invalid echonext.Response[any]
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &invalid))
+			if assert.Len(t, invalid.Details, 1, body) {
+				assert.Equal(t, field, invalid.Details[0].Field, body)
+				assert.Contains(t, invalid.Error, field, body)
+			}
+		}
+	})
+
+	t.Run("documented with wire names", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		account := spec.Components.Schemas["Customer"].Value
+		var names []string
+		for name := range account.Properties {
+			names = append(names, name)
+		}
+		assert.ElementsMatch(t, []string{"id", "display_name", "http_port", "owner", "labels", "created_at"}, names)
+		assert.Equal(t, []string{"display_name"}, account.Required)
+		assert.Contains(t, spec.Components.Schemas["CustomerOwner"].Value.Properties, "full_name")
+	})
+
+	t.Run("camelCase", func(t *testing.T) {
+		app := echonext.New()
+		app.SetNamingStrategy(echonext.NamingCamelCase)
+		app.GET("/customer", func(c echo.Context) (Customer, error) {
+			return Customer{CustomerID: "a1", DisplayName: "Ann", HTTPPort: 1}, nil
+		})
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/customer", nil))
+		assert.Contains(t, rec.Body.String(), `"displayName":"Ann"`)
+		assert.Contains(t, rec.Body.String(), `"httpPort":1`)
+		assert.Contains(t, app.GenerateOpenAPISpec().Components.Schemas["Customer"].Value.Properties, "httpPort")
+	})
+}
+
+func TestNamingStrategyXML(t *testing.T) {
+	app := echonext.New()
+	app.SetNamingStrategy(echonext.NamingCamelCase)
+	app.GET("/books/:id", func(c echo.Context) (Book, error) {
+		return Book{ID: c.Param("id"), Title: "Dune"}, nil
+	}, echonext.Route{ContentTypes: []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML}})
+
+	// Names apply to JSON; XML keeps its own element names
+	req := httptest.NewRequest(http.MethodGet, "/books/1", nil)
+	req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationXML)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationXML)
+
+	var response echonext.Response[Book]
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, Book{ID: "1", Title: "Dune"}, response.Data)
+}
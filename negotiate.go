@@ -0,0 +1,127 @@
+package echonext
+
+import (
+	"encoding/xml"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// responseFormatKey holds the media type negotiated for the response
+const responseFormatKey = "echonext.format"
+
+// responseTypes lists the media types a route responds with. JSON is always
+// offered; XML is offered when the route lists it in ContentTypes.
+func responseTypes(route *Route) []string {
+	types := []string{echo.MIMEApplicationJSON}
+	if route == nil {
+		return types
+	}
+	for _, contentType := range route.ContentTypes {
+		if contentType == echo.MIMEApplicationXML {
+			return append(types, echo.MIMEApplicationXML)
+		}
+	}
+	return types
+}
+
+// jsonOnly reports whether responses of type t are only sent as JSON. Int
+// enum names and scoped fields are applied to the JSON encoding, so XML would
+// carry raw enum values and fields the caller may not see.
+func (app *App) jsonOnly(t reflect.Type) bool {
+	return t != nil && (app.hasIntEnums(t) || hasScopedFields(t))
+}
+
+// offeredTypes lists the media types route sends responses of type t in
+func (app *App) offeredTypes(route *Route, t reflect.Type) []string {
+	types := responseTypes(route)
+	if app.jsonOnly(t) {
+		return types[:1]
+	}
+	return types
+}
+
+// negotiateFormat records the response format negotiated for route before
+// route middleware runs, so responses sent by middleware follow Accept too.
+// Requests accepting no offered type are left for the handler to reject.
+func (app *App) negotiateFormat(route *Route, t reflect.Type) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			format, ok := negotiate(c.Request().Header.Get(echo.HeaderAccept), app.offeredTypes(route, t))
+			if ok && format != echo.MIMEApplicationJSON {
+				c.Set(responseFormatKey, format)
+			}
+			return next(c)
+		}
+	}
+}
+
+// negotiate picks the offered media type the Accept header ranks highest,
+// preferring earlier offers on ties. It reports false when the client
+// accepts none of them.
+func negotiate(accept string, offered []string) (string, bool) {
+	if accept == "*/*" || strings.TrimSpace(accept) == "" {
+		return offered[0], true
+	}
+
+	best, bestQuality := "", 0.0
+	for _, offer := range offered {
+		if quality := acceptQuality(accept, offer); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best, best != ""
+}
+
+// acceptQuality returns the q value of the most specific Accept range
+// matching mediaType, or 0 when none match
+func acceptQuality(accept, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		accepted, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		rank := -1
+		switch {
+		case accepted == mediaType:
+			rank = 2
+		case accepted == "*/*":
+			rank = 0
+		case strings.HasSuffix(accepted, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(accepted, "*")):
+			rank = 1
+		}
+		if rank <= specificity {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+		quality, specificity = q, rank
+	}
+	return quality
+}
+
+// respond writes body in the media type negotiated for the request. XML is
+// encoded before the status is sent, so bodies encoding/xml can't encode,
+// such as maps, get a 500 error envelope in JSON instead of an empty 200.
+func respond(c echo.Context, status int, body interface{}) error {
+	if format, _ := c.Get(responseFormatKey).(string); format == echo.MIMEApplicationXML {
+		data, err := xml.Marshal(body)
+		if err != nil {
+			c.Set(responseFormatKey, echo.MIMEApplicationJSON)
+			return errorResponse(c, http.StatusInternalServerError, "Response can't be encoded as XML: "+err.Error())
+		}
+		return c.XMLBlob(status, data)
+	}
+	return c.JSON(status, body)
+}
@@ -0,0 +1,152 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Book struct {
+	ID    string `json:"id" xml:"id"`
+	Title string `json:"title" xml:"title"`
+}
+
+func TestContentNegotiation(t *testing.T) {
+	app := echonext.New()
+	app.GET("/books/:id", func(c echo.Context) (Book, error) {
+		return Book{ID: c.Param("id"), Title: "Dune"}, nil
+	}, echonext.Route{ContentTypes: []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML}})
+	app.GET("/authors", func(c echo.Context) ([]TestUser, error) {
+		return []TestUser{{ID: "1"}}, nil
+	})
+	app.GET("/missing", func(c echo.Context) (Book, error) {
+		return Book{}, echo.NewHTTPError(http.StatusNotFound, "book not found")
+	}, echonext.Route{ContentTypes: []string{echo.MIMEApplicationXML}})
+
+	serve := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("xml when requested", func(t *testing.T) {
+		rec := serve("/books/1", "application/xml")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationXML)
+
+		var response echonext.Response[Book]
+		assert.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, Book{ID: "1", Title: "Dune"}, response.Data)
+	})
+
+	t.Run("json by default", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json, application/xml", "application/xml;q=0.5, application/*;q=0.9"} {
+			rec := serve("/books/1", accept)
+			assert.Equal(t, http.StatusOK, rec.Code, accept)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON, accept)
+		}
+	})
+
+	t.Run("xml errors", func(t *testing.T) {
+		rec := serve("/missing", "application/xml")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, xml.Header+`<response><error>book not found</error><success>false</success></response>`, rec.Body.String())
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		rec := serve("/books/1", "text/html, application/json;q=0")
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Contains(t, response.Error, "Not acceptable")
+	})
+
+	t.Run("single type not negotiated", func(t *testing.T) {
+		for _, accept := range []string{"application/xml", "text/plain", "application/problem+json", "application/vnd.api+json"} {
+			rec := serve("/authors", accept)
+			assert.Equal(t, http.StatusOK, rec.Code, accept)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON, accept)
+		}
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		books := spec.Paths["/books/{id}"].Get.Responses
+		assert.Contains(t, books["200"].Value.Content, echo.MIMEApplicationXML)
+		assert.Contains(t, books, "406")
+
+		authors := spec.Paths["/authors"].Get.Responses
+		assert.NotContains(t, authors["200"].Value.Content, echo.MIMEApplicationXML)
+		assert.NotContains(t, authors, "406")
+	})
+}
+
+func TestContentNegotiationJSONOnlyResponses(t *testing.T) {
+	app := echonext.New()
+	app.RegisterIntEnum(PriorityLow, map[Priority]string{PriorityLow: "low", PriorityHigh: "high"})
+	app.SetScopeResolver(func(c echo.Context) []string { return nil })
+
+	xmlRoute := echonext.Route{ContentTypes: []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML}}
+	app.GET("/tasks/:id", func(c echo.Context) (Task, error) {
+		return Task{Title: "Ship", Priority: PriorityHigh}, nil
+	}, xmlRoute)
+	app.GET("/accounts/:id", func(c echo.Context) (Account, error) {
+		return Account{ID: "1", Internal: "VIP customer"}, nil
+	}, xmlRoute)
+
+	// Enum names and scoped fields only apply to JSON, so XML isn't offered
+	for _, path := range []string{"/tasks/1", "/accounts/1"} {
+		for _, accept := range []string{echo.MIMEApplicationXML, "application/xml, application/json;q=0.5"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set(echo.HeaderAccept, accept)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code, path)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON, path)
+			assert.NotContains(t, rec.Body.String(), "VIP customer", path)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
+	req.Header.Set(echo.HeaderAccept, "application/xml, application/json;q=0.5")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Contains(t, rec.Body.String(), `"priority":"high"`)
+
+	spec := app.GenerateOpenAPISpec()
+	for _, path := range []string{"/tasks/{id}", "/accounts/{id}"} {
+		responses := spec.Paths[path].Get.Responses
+		assert.NotContains(t, responses["200"].Value.Content, echo.MIMEApplicationXML, path)
+		assert.NotContains(t, responses, "406", path)
+	}
+}
+
+func TestContentNegotiationUnencodableXML(t *testing.T) {
+	app := echonext.New()
+	app.GET("/labels", func(c echo.Context) (map[string]string, error) {
+		return map[string]string{"color": "red"}, nil
+	}, echonext.Route{ContentTypes: []string{echo.MIMEApplicationJSON, echo.MIMEApplicationXML}})
+
+	// encoding/xml can't encode maps, so the failure is reported in JSON
+	req := httptest.NewRequest(http.MethodGet, "/labels", nil)
+	req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationXML)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Error, "can't be encoded as XML")
+}
@@ -0,0 +1,61 @@
+package echonext
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OAuthFlows configures the flows an oauth2 security scheme supports. At
+// least one flow must be set.
+type OAuthFlows struct {
+	AuthorizationCode *OAuthFlow
+	ClientCredentials *OAuthFlow
+	Implicit          *OAuthFlow
+	Password          *OAuthFlow
+}
+
+// OAuthFlow describes the endpoints and scopes of an OAuth2 flow
+type OAuthFlow struct {
+	AuthorizationURL string            // For the authorizationCode and implicit flows
+	TokenURL         string            // For the authorizationCode, clientCredentials and password flows
+	RefreshURL       string            // Optional
+	Scopes           map[string]string // Scope name to description
+}
+
+// oauthFlows converts flows for the spec, rejecting a scheme without flows
+// and flows missing the URLs they require
+func oauthFlows(flows *OAuthFlows) (*openapi3.OAuthFlows, error) {
+	if flows == nil || (flows.AuthorizationCode == nil && flows.ClientCredentials == nil &&
+		flows.Implicit == nil && flows.Password == nil) {
+		return nil, fmt.Errorf("oauth2 scheme needs at least one flow")
+	}
+
+	converted := &openapi3.OAuthFlows{
+		AuthorizationCode: oauthFlow(flows.AuthorizationCode),
+		ClientCredentials: oauthFlow(flows.ClientCredentials),
+		Implicit:          oauthFlow(flows.Implicit),
+		Password:          oauthFlow(flows.Password),
+	}
+	if err := converted.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
+
+func oauthFlow(flow *OAuthFlow) *openapi3.OAuthFlow {
+	if flow == nil {
+		return nil
+	}
+	scopes := make(map[string]string, len(flow.Scopes))
+	for name, description := range flow.Scopes {
+		scopes[name] = description
+	}
+	return &openapi3.OAuthFlow{
+		AuthorizationURL: flow.AuthorizationURL,
+		TokenURL:         flow.TokenURL,
+		RefreshURL:       flow.RefreshURL,
+		Scopes:           scopes,
+	}
+}
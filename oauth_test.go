@@ -0,0 +1,59 @@
+package echonext_test
+
+import (
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuth2SecurityScheme(t *testing.T) {
+	app := echonext.New()
+	app.AddSecurityScheme("oauth2", echonext.Security{
+		Type: "oauth2",
+		Flows: &echonext.OAuthFlows{
+			AuthorizationCode: &echonext.OAuthFlow{
+				AuthorizationURL: "https://auth.example.com/authorize",
+				TokenURL:         "https://auth.example.com/token",
+				Scopes:           map[string]string{"todos:read": "Read todos", "todos:write": "Modify todos"},
+			},
+			ClientCredentials: &echonext.OAuthFlow{
+				TokenURL: "https://auth.example.com/token",
+			},
+		},
+	})
+	app.POST("/todos", func(c echo.Context) (TestUser, error) { return TestUser{ID: "1"}, nil }, echonext.Route{
+		Security: []echonext.Security{{Type: "oauth2", Scopes: []string{"todos:write"}}},
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	scheme := spec.Components.SecuritySchemes["oauth2"].Value
+	assert.Equal(t, "oauth2", scheme.Type)
+	assert.Equal(t, "https://auth.example.com/authorize", scheme.Flows.AuthorizationCode.AuthorizationURL)
+	assert.Equal(t, "Modify todos", scheme.Flows.AuthorizationCode.Scopes["todos:write"])
+	assert.Equal(t, map[string]string{}, scheme.Flows.ClientCredentials.Scopes)
+	assert.Nil(t, scheme.Flows.Implicit)
+
+	assert.Equal(t, &openapi3.SecurityRequirements{{"oauth2": {"todos:write"}}}, spec.Paths["/todos"].Post.Security)
+	assert.NoError(t, app.ValidateSpec())
+
+	t.Run("rejects schemes without flows", func(t *testing.T) {
+		assert.PanicsWithValue(t, `echonext: security scheme "oauth2": oauth2 scheme needs at least one flow`, func() {
+			echonext.New().AddSecurityScheme("oauth2", echonext.Security{Type: "oauth2"})
+		})
+		assert.Panics(t, func() {
+			echonext.New().AddSecurityScheme("oauth2", echonext.Security{Type: "oauth2", Flows: &echonext.OAuthFlows{}})
+		})
+	})
+
+	t.Run("rejects flows missing URLs", func(t *testing.T) {
+		assert.Panics(t, func() {
+			echonext.New().AddSecurityScheme("oauth2", echonext.Security{
+				Type:  "oauth2",
+				Flows: &echonext.OAuthFlows{ClientCredentials: &echonext.OAuthFlow{}},
+			})
+		})
+	})
+}
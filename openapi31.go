@@ -0,0 +1,140 @@
+package echonext
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Supported OpenAPI document versions
+const (
+	OpenAPI30 = "3.0.0"
+	OpenAPI31 = "3.1.0"
+)
+
+// SetOpenAPIVersion selects the OpenAPI version of the served document.
+// OpenAPI30 is the default. With OpenAPI31 schemas are rewritten to JSON
+// Schema 2020-12: nullable types become type arrays like ["string", "null"]
+// and examples use the examples keyword.
+func (app *App) SetOpenAPIVersion(version string) {
+	if !strings.HasPrefix(version, "3.0.") && !strings.HasPrefix(version, "3.1.") {
+		panic(fmt.Sprintf("echonext: unsupported OpenAPI version %q", version))
+	}
+	app.spec.OpenAPI = version
+}
+
+// MarshalOpenAPISpec generates the spec and encodes it as JSON in the
+// selected OpenAPI version. It is safe to call from concurrent requests.
+func (app *App) MarshalOpenAPISpec() ([]byte, error) {
+	return app.marshalSpec(nil)
+}
+
+// marshalSpec generates and encodes the spec while holding the spec lock,
+// passing the document and its JSON encoding to inspect when it is set
+func (app *App) marshalSpec(inspect func(spec *openapi3.T, data []byte)) ([]byte, error) {
+	app.specMu.Lock()
+	spec := app.generateSpec()
+	data, err := json.Marshal(spec)
+	if inspect != nil && err == nil {
+		inspect(spec, data)
+	}
+	version := spec.OpenAPI
+	app.specMu.Unlock()
+	if err != nil || !strings.HasPrefix(version, "3.1.") {
+		return data, err
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	convertSchemas31(doc)
+	return json.Marshal(doc)
+}
+
+// convertSchemas31 finds the schemas in a decoded document and rewrites them
+// for OpenAPI 3.1
+func convertSchemas31(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			switch key {
+			case "example", "examples":
+				// Example values are data, not schemas
+			case "schema":
+				convertSchema31(child)
+			case "schemas":
+				if schemas, ok := child.(map[string]interface{}); ok {
+					for _, schema := range schemas {
+						convertSchema31(schema)
+					}
+				}
+			default:
+				convertSchemas31(child)
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			convertSchemas31(child)
+		}
+	}
+}
+
+// convertSchema31 rewrites a 3.0 schema object and its subschemas in place
+func convertSchema31(v interface{}) {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		if t, ok := schema["type"].(string); ok {
+			schema["type"] = []interface{}{t, "null"}
+		} else if _, isRef := schema["$ref"]; !isRef {
+			// Untyped schemas such as allOf wrappers also accept null
+			inner := make(map[string]interface{}, len(schema))
+			for key, value := range schema {
+				if key != "nullable" && key != "description" {
+					inner[key] = value
+					delete(schema, key)
+				}
+			}
+			schema["anyOf"] = []interface{}{inner, map[string]interface{}{"type": "null"}}
+		}
+	}
+	delete(schema, "nullable")
+
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{example}
+		delete(schema, "example")
+	}
+
+	// Exclusive bounds are numbers rather than flags on minimum and maximum
+	for _, bound := range []string{"minimum", "maximum"} {
+		flag := "exclusive" + strings.ToUpper(bound[:1]) + bound[1:]
+		if exclusive, _ := schema[flag].(bool); exclusive {
+			schema[flag] = schema[bound]
+			delete(schema, bound)
+		} else {
+			delete(schema, flag)
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		convertSchema31(schema[key])
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema[key].([]interface{}); ok {
+			for _, sub := range list {
+				convertSchema31(sub)
+			}
+		}
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, sub := range properties {
+			convertSchema31(sub)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Listing struct {
+	Title    string            `json:"title" example:"Loft"`
+	Status   *string           `json:"status" validate:"omitempty,oneof=draft live"`
+	Photos   []string          `json:"photos"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func TestOpenAPI31(t *testing.T) {
+	newApp := func() *echonext.App {
+		app := echonext.New()
+		app.GET("/listings", func(c echo.Context) ([]Listing, error) {
+			return nil, nil
+		})
+		app.ServeOpenAPISpec("/openapi.json")
+		return app
+	}
+
+	fetch := func(app *echonext.App) map[string]interface{} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		return doc
+	}
+
+	listing := func(doc map[string]interface{}) map[string]interface{} {
+		schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+		return schemas["Listing"].(map[string]interface{})["properties"].(map[string]interface{})
+	}
+
+	t.Run("3.0 default", func(t *testing.T) {
+		doc := fetch(newApp())
+		assert.Equal(t, echonext.OpenAPI30, doc["openapi"])
+		status := listing(doc)["status"].(map[string]interface{})
+		assert.Equal(t, "string", status["type"])
+		assert.Equal(t, true, status["nullable"])
+		assert.Equal(t, "Loft", listing(doc)["title"].(map[string]interface{})["example"])
+	})
+
+	t.Run("3.1", func(t *testing.T) {
+		app := newApp()
+		app.SetOpenAPIVersion(echonext.OpenAPI31)
+		doc := fetch(app)
+		assert.Equal(t, echonext.OpenAPI31, doc["openapi"])
+
+		properties := listing(doc)
+		status := properties["status"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"string", "null"}, status["type"])
+		assert.NotContains(t, status, "nullable")
+		assert.Contains(t, status["enum"], nil)
+
+		title := properties["title"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"Loft"}, title["examples"])
+		assert.NotContains(t, title, "example")
+
+		photos := properties["photos"].(map[string]interface{})
+		assert.Equal(t, "array", photos["type"])
+		assert.Equal(t, "string", photos["items"].(map[string]interface{})["type"])
+
+		metadata := properties["metadata"].(map[string]interface{})
+		assert.Equal(t, "object", metadata["type"])
+		assert.Equal(t, "string", metadata["additionalProperties"].(map[string]interface{})["type"])
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		assert.Panics(t, func() { echonext.New().SetOpenAPIVersion("2.0") })
+	})
+}
@@ -0,0 +1,144 @@
+package echonext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// OperationIDStrategy controls how operation IDs are derived for routes
+type OperationIDStrategy int
+
+const (
+	// OperationIDNone leaves operations without an operationId unless the
+	// route pins one
+	OperationIDNone OperationIDStrategy = iota
+	// OperationIDMethodPath derives IDs from the method and path, e.g.
+	// GET /todos/:id becomes getTodosById
+	OperationIDMethodPath
+	// OperationIDHandlerName uses the handler function's name, falling back to
+	// the method and path for anonymous handlers and names already taken
+	OperationIDHandlerName
+	// OperationIDHandlerNameWithSuffix uses the handler function's name and
+	// disambiguates reused handlers by appending the method, then a counter.
+	// Anonymous handlers use the method and path. This is the default.
+	OperationIDHandlerNameWithSuffix
+)
+
+// SetOperationIDStrategy sets how operation IDs are derived for routes without
+// Route.OperationID. IDs are always unique within the spec.
+func (app *App) SetOperationIDStrategy(strategy OperationIDStrategy) {
+	app.operationIDStrategy = strategy
+}
+
+// anonymousFunc matches the names Go gives closures, e.g. "main.func1"
+var anonymousFunc = regexp.MustCompile(`(^|\.)func\d+(\.\d+)*$`)
+
+// operationIDs assigns an operation ID to each route in order. IDs pinned
+// with Route.OperationID are reserved first; a pin repeated on a later route
+// gets the method appended, then a counter.
+func (app *App) operationIDs(routes []RouteInfo) []string {
+	ids := make([]string, len(routes))
+	taken := make(map[string]bool, len(routes))
+	for i, route := range routes {
+		if pinned := route.pinnedOperationID(); pinned != "" && !taken[pinned] {
+			ids[i] = pinned
+			taken[pinned] = true
+		}
+	}
+
+	for i, route := range routes {
+		if ids[i] != "" {
+			continue
+		}
+		if pinned := route.pinnedOperationID(); pinned != "" {
+			ids[i] = uniqueID(pinned+exportedName(strings.ToLower(route.Method)), taken)
+			taken[ids[i]] = true
+			continue
+		}
+		if app.operationIDStrategy == OperationIDNone {
+			continue
+		}
+
+		pathID := methodPathID(route.Method, route.Path)
+		name := handlerOperationName(route.Handler)
+
+		var id string
+		switch {
+		case app.operationIDStrategy == OperationIDMethodPath || name == "":
+			id = pathID
+		case app.operationIDStrategy == OperationIDHandlerName:
+			id = name
+			if taken[id] {
+				id = pathID
+			}
+		case taken[name]:
+			id = name + exportedName(strings.ToLower(route.Method))
+		default:
+			id = name
+		}
+
+		ids[i] = uniqueID(id, taken)
+		taken[ids[i]] = true
+	}
+	return ids
+}
+
+// pinnedOperationID returns the route's Route.OperationID, if any
+func (r RouteInfo) pinnedOperationID() string {
+	if r.RouteConfig == nil {
+		return ""
+	}
+	return r.RouteConfig.OperationID
+}
+
+// uniqueID appends the smallest counter from 2 up that makes id unused
+func uniqueID(id string, taken map[string]bool) string {
+	if !taken[id] {
+		return id
+	}
+	for n := 2; ; n++ {
+		if candidate := id + strconv.Itoa(n); !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// handlerOperationName returns the function name of handler, or "" for
+// closures whose names mean nothing to API clients
+func handlerOperationName(handler interface{}) string {
+	name := handlerName(handler)
+	if name == "" || anonymousFunc.MatchString(name) {
+		return ""
+	}
+	// Method values are named after the method, not the receiver
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// methodPathID builds a camel-case ID from the method and path segments, with
+// path parameters written as "By<Name>"
+func methodPathID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			b.WriteString("By")
+			segment = segment[1:]
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			b.WriteString(exportedName(word))
+		}
+	}
+	return b.String()
+}
+
+// exportedName upper-cases the first letter of s
+func exportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,100 @@
+package echonext_test
+
+import (
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTodo(c echo.Context) (TestUser, error) { return TestUser{}, nil }
+
+func saveTodo(c echo.Context, req CreateUserRequest) (TestUser, error) { return TestUser{}, nil }
+
+func TestOperationIDStrategies(t *testing.T) {
+	newApp := func(strategy echonext.OperationIDStrategy) *echonext.App {
+		app := echonext.New()
+		app.SetOperationIDStrategy(strategy)
+		app.GET("/todos/:id", getTodo)
+		app.GET("/archive/todos/:id", getTodo)
+		app.POST("/todos", saveTodo)
+		app.PUT("/todos/:id", saveTodo)
+		app.DELETE("/todos/:id", func(c echo.Context) error { return nil })
+		return app
+	}
+
+	ids := func(app *echonext.App) []string {
+		spec := app.GenerateOpenAPISpec()
+		return []string{
+			spec.Paths["/todos/{id}"].Get.OperationID,
+			spec.Paths["/archive/todos/{id}"].Get.OperationID,
+			spec.Paths["/todos"].Post.OperationID,
+			spec.Paths["/todos/{id}"].Put.OperationID,
+			spec.Paths["/todos/{id}"].Delete.OperationID,
+		}
+	}
+
+	t.Run("none", func(t *testing.T) {
+		assert.Equal(t, []string{"", "", "", "", ""}, ids(newApp(echonext.OperationIDNone)))
+	})
+
+	t.Run("method and path", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"getTodosById", "getArchiveTodosById", "postTodos", "putTodosById", "deleteTodosById",
+		}, ids(newApp(echonext.OperationIDMethodPath)))
+	})
+
+	t.Run("handler name", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"getTodo", "getArchiveTodosById", "saveTodo", "putTodosById", "deleteTodosById",
+		}, ids(newApp(echonext.OperationIDHandlerName)))
+	})
+
+	t.Run("handler name with suffix", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"getTodo", "getTodoGet", "saveTodo", "saveTodoPut", "deleteTodosById",
+		}, ids(newApp(echonext.OperationIDHandlerNameWithSuffix)))
+	})
+
+	t.Run("stable across generations", func(t *testing.T) {
+		app := newApp(echonext.OperationIDHandlerNameWithSuffix)
+		assert.Equal(t, ids(app), ids(app))
+	})
+}
+
+func TestOperationIDs(t *testing.T) {
+	t.Run("on by default", func(t *testing.T) {
+		app := echonext.New()
+		app.GET("/todos/:id", getTodo)
+		app.GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+
+		spec := app.GenerateOpenAPISpec()
+		assert.Equal(t, "getTodo", spec.Paths["/todos/{id}"].Get.OperationID)
+		assert.Equal(t, "getUsers", spec.Paths["/users"].Get.OperationID)
+	})
+
+	t.Run("pinned", func(t *testing.T) {
+		app := echonext.New()
+		app.SetOperationIDStrategy(echonext.OperationIDNone)
+		app.GET("/getTodo", getTodo)
+		app.GET("/todos", func(c echo.Context) ([]TestUser, error) { return nil, nil }, echonext.Route{OperationID: "listTodos"})
+		app.DELETE("/todos", func(c echo.Context) error { return nil }, echonext.Route{OperationID: "listTodos"})
+
+		spec := app.GenerateOpenAPISpec()
+		assert.Empty(t, spec.Paths["/getTodo"].Get.OperationID)
+		assert.Equal(t, "listTodos", spec.Paths["/todos"].Get.OperationID)
+		assert.Equal(t, "listTodosDelete", spec.Paths["/todos"].Delete.OperationID)
+	})
+
+	t.Run("pins are reserved before derived IDs", func(t *testing.T) {
+		app := echonext.New()
+		app.GET("/todos/:id", getTodo)
+		app.GET("/todos/:id/latest", getTodo, echonext.Route{OperationID: "getTodo"})
+
+		spec := app.GenerateOpenAPISpec()
+		assert.Equal(t, "getTodo", spec.Paths["/todos/{id}/latest"].Get.OperationID)
+		assert.Equal(t, "getTodoGet", spec.Paths["/todos/{id}"].Get.OperationID)
+		assert.NoError(t, app.ValidateSpec())
+	})
+}
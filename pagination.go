@@ -0,0 +1,93 @@
+package echonext
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderLink carries the links between pages of a collection
+const HeaderLink = "Link"
+
+// Page is one page of a collection, with the metadata clients need to
+// request the others. Build it with Paginate.
+type Page[T any] struct {
+	Items      []T `json:"items"`
+	TotalCount int `json:"total_count"`
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalPages int `json:"total_pages"`
+}
+
+func (Page[T]) paginated() {}
+
+// paginated is implemented by every Page, so routes returning one document
+// the Link header
+type paginated interface{ paginated() }
+
+var paginatedType = reflect.TypeOf((*paginated)(nil)).Elem()
+
+// Paginate returns page of a collection of total items, holding items, and
+// sets the Link header to the first, last, previous and next pages. The links
+// repeat the request's URL with the page and limit query parameters replaced.
+// Pages count from 1; a limit below 1 puts everything on one page.
+func Paginate[T any](c echo.Context, items []T, total, page, limit int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	if page < 1 {
+		page = 1
+	}
+	result := Page[T]{Items: items, TotalCount: total, Page: page, Limit: limit, TotalPages: 1}
+	if limit < 1 {
+		return result
+	}
+	if total > limit {
+		result.TotalPages = (total + limit - 1) / limit
+	}
+
+	last := result.TotalPages
+	links := []string{pageLink(c, 1, limit, "first")}
+	if page > 1 {
+		links = append(links, pageLink(c, min(page-1, last), limit, "prev"))
+	}
+	if page < last {
+		links = append(links, pageLink(c, page+1, limit, "next"))
+	}
+	links = append(links, pageLink(c, last, limit, "last"))
+	c.Response().Header().Set(HeaderLink, strings.Join(links, ", "))
+	return result
+}
+
+// pageLink returns a Link header entry for a page of the requested collection
+func pageLink(c echo.Context, page, limit int, rel string) string {
+	u := *c.Request().URL
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	return "<" + u.Path + "?" + query.Encode() + `>; rel="` + rel + `"`
+}
+
+// isPaginated reports whether a response type is a Page
+func isPaginated(t reflect.Type) bool {
+	return t != nil && t.Implements(paginatedType)
+}
+
+// paginationHeaders documents the Link header sent with pages
+func paginationHeaders() openapi3.Headers {
+	return openapi3.Headers{
+		HeaderLink: &openapi3.HeaderRef{
+			Value: &openapi3.Header{
+				Parameter: openapi3.Parameter{
+					Description: `Links to the first, last, previous and next pages, e.g. </todos?limit=10&page=2>; rel="next"`,
+					Schema: &openapi3.SchemaRef{
+						Value: &openapi3.Schema{Type: "string"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ListNotesRequest struct {
+	Page  int    `query:"page" default:"1"`
+	Limit int    `query:"limit" default:"2"`
+	Q     string `query:"q"`
+}
+
+func TestPaginate(t *testing.T) {
+	notes := []string{"a", "b", "c", "d", "e"}
+
+	app := echonext.New()
+	app.GET("/notes", func(c echo.Context, req ListNotesRequest) (echonext.Page[string], error) {
+		start := min((req.Page-1)*req.Limit, len(notes))
+		end := min(start+req.Limit, len(notes))
+		return echonext.Paginate(c, notes[start:end], len(notes), req.Page, req.Limit), nil
+	})
+
+	get := func(query string) (*httptest.ResponseRecorder, echonext.Page[string]) {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/notes?"+query, nil))
+		var response echonext.Response[echonext.Page[string]]
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+		return rec, response.Data
+	}
+
+	t.Run("middle page", func(t *testing.T) {
+		rec, page := get("page=2&q=x")
+		assert.Equal(t, echonext.Page[string]{Items: []string{"c", "d"}, TotalCount: 5, Page: 2, Limit: 2, TotalPages: 3}, page)
+		assert.Equal(t, `</notes?limit=2&page=1&q=x>; rel="first", `+
+			`</notes?limit=2&page=1&q=x>; rel="prev", `+
+			`</notes?limit=2&page=3&q=x>; rel="next", `+
+			`</notes?limit=2&page=3&q=x>; rel="last"`, rec.Header().Get(echonext.HeaderLink))
+	})
+
+	t.Run("first page", func(t *testing.T) {
+		rec, page := get("")
+		assert.Equal(t, []string{"a", "b"}, page.Items)
+		assert.Equal(t, `</notes?limit=2&page=1>; rel="first", `+
+			`</notes?limit=2&page=2>; rel="next", `+
+			`</notes?limit=2&page=3>; rel="last"`, rec.Header().Get(echonext.HeaderLink))
+	})
+
+	t.Run("past the end", func(t *testing.T) {
+		rec, page := get("page=9")
+		assert.Equal(t, []string{}, page.Items)
+		assert.Equal(t, `</notes?limit=2&page=1>; rel="first", `+
+			`</notes?limit=2&page=3>; rel="prev", `+
+			`</notes?limit=2&page=3>; rel="last"`, rec.Header().Get(echonext.HeaderLink))
+	})
+
+	t.Run("empty collection", func(t *testing.T) {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/notes", nil), httptest.NewRecorder())
+		page := echonext.Paginate[string](c, nil, 0, 1, 10)
+		assert.Equal(t, echonext.Page[string]{Items: []string{}, Page: 1, Limit: 10, TotalPages: 1}, page)
+		assert.Equal(t, `</notes?limit=10&page=1>; rel="first", </notes?limit=10&page=1>; rel="last"`, c.Response().Header().Get(echonext.HeaderLink))
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		response := spec.Paths["/notes"].Get.Responses["200"].Value
+		assert.Contains(t, response.Headers, echonext.HeaderLink)
+		assert.Contains(t, spec.Components.Schemas["PageString"].Value.Properties, "total_pages")
+	})
+}
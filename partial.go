@@ -0,0 +1,96 @@
+package echonext
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// requestBodyKey holds the *providedFields of a JSON request body
+const requestBodyKey = "echonext.body"
+
+// providedFields is a JSON request body kept for presence checks, decoded
+// into its top-level keys on first use
+type providedFields struct {
+	data   []byte
+	fields map[string]json.RawMessage
+	parsed bool
+}
+
+// Provided reports whether the request body set a field, even to null or an
+// empty value, so PATCH handlers can tell "set to empty" from "not sent".
+// field is the JSON key, with dots for nested objects such as "address.city";
+// for form bodies it is the form key. Bodies are kept for routes whose request
+// struct has pointer fields and for PATCH requests.
+func Provided(c echo.Context, field string) bool {
+	body, ok := c.Get(requestBodyKey).(*providedFields)
+	if !ok {
+		if isFormRequest(c) {
+			form, err := c.FormParams()
+			return err == nil && form.Has(field)
+		}
+		return false
+	}
+
+	if !body.parsed {
+		body.parsed = true
+		_ = json.Unmarshal(body.data, &body.fields)
+	}
+	fields := body.fields
+	segments := strings.Split(field, ".")
+	for i, segment := range segments {
+		raw, ok := fields[segment]
+		if !ok {
+			return false
+		}
+		if i == len(segments)-1 {
+			return true
+		}
+		fields = nil
+		if json.Unmarshal(raw, &fields) != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// keepsBody reports whether requests of type t have their body kept for
+// Provided, because t has pointer fields for partial updates
+func keepsBody(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Kind() == reflect.Ptr {
+			return true
+		}
+	}
+	return false
+}
+
+// keepBody reads a JSON request body for Provided and restores it for
+// binding. It returns the error reading the body, if any.
+func keepBody(c echo.Context) error {
+	req := c.Request()
+	if req.Body == nil || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	c.Set(requestBodyKey, &providedFields{data: data})
+	return nil
+}
@@ -0,0 +1,75 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvided(t *testing.T) {
+	type PatchNoteRequest struct {
+		Title   string  `json:"title" form:"title"`
+		Body    *string `json:"body" form:"body"`
+		Address struct {
+			City string `json:"city"`
+		} `json:"address"`
+	}
+	type PutNoteRequest struct {
+		Title string `json:"title"`
+	}
+
+	var provided map[string]bool
+	record := func(c echo.Context) {
+		provided = map[string]bool{}
+		for _, field := range []string{"title", "body", "address", "address.city", "missing.city"} {
+			provided[field] = echonext.Provided(c, field)
+		}
+	}
+
+	app := echonext.New()
+	app.PATCH("/notes/:id", func(c echo.Context, req PatchNoteRequest) (TestUser, error) {
+		record(c)
+		return TestUser{ID: "1"}, nil
+	})
+	app.PUT("/notes/:id", func(c echo.Context, req PutNoteRequest) (TestUser, error) {
+		record(c)
+		return TestUser{ID: "1"}, nil
+	})
+	app.POST("/notes", func(c echo.Context, req PatchNoteRequest) (TestUser, error) {
+		record(c)
+		return TestUser{ID: "1"}, nil
+	})
+
+	send := func(method, path, contentType, body string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	send(http.MethodPatch, "/notes/1", echo.MIMEApplicationJSON, `{"title":"","body":null,"address":{"city":"Oslo"}}`)
+	assert.Equal(t, map[string]bool{"title": true, "body": true, "address": true, "address.city": true, "missing.city": false}, provided)
+
+	send(http.MethodPatch, "/notes/1", echo.MIMEApplicationJSON, `{"body":"text"}`)
+	assert.Equal(t, map[string]bool{"title": false, "body": true, "address": false, "address.city": false, "missing.city": false}, provided)
+
+	// Pointer DTOs keep their bodies whatever the method
+	send(http.MethodPost, "/notes", echo.MIMEApplicationJSON, `{"title":"x"}`)
+	assert.True(t, provided["title"])
+
+	// Other requests are only kept for PATCH
+	send(http.MethodPut, "/notes/1", echo.MIMEApplicationJSON, `{"title":"x"}`)
+	assert.False(t, provided["title"])
+
+	form := url.Values{"title": {""}}
+	send(http.MethodPatch, "/notes/1", echo.MIMEApplicationForm, form.Encode())
+	assert.True(t, provided["title"])
+	assert.False(t, provided["body"])
+}
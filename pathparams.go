@@ -0,0 +1,175 @@
+package echonext
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// pathSliceField is a request field bound from a delimited path segment,
+// e.g. `param:"ids" delimiter:","` matching /items/1,2,3
+type pathSliceField struct {
+	index     int
+	name      string
+	delimiter string
+}
+
+// pathSliceFields returns the slice fields of t bound from path parameters
+func pathSliceFields(t reflect.Type) []pathSliceField {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []pathSliceField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("param")
+		if name == "" || name == "-" || field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		fields = append(fields, pathSliceField{
+			index:     i,
+			name:      name,
+			delimiter: pathDelimiter(field),
+		})
+	}
+	return fields
+}
+
+// pathDelimiter returns the delimiter separating elements of a slice path parameter
+func pathDelimiter(field reflect.StructField) string {
+	if delimiter := field.Tag.Get("delimiter"); delimiter != "" {
+		return delimiter
+	}
+	return ","
+}
+
+// withoutPathParams runs fn with the named path parameters hidden from c so
+// Echo's binder doesn't try to bind delimited values into slices
+func withoutPathParams(c echo.Context, fields []pathSliceField, fn func() error) error {
+	if len(fields) == 0 {
+		return fn()
+	}
+
+	names, values := c.ParamNames(), c.ParamValues()
+	var keptNames, keptValues []string
+	for i, name := range names {
+		hidden := false
+		for _, field := range fields {
+			if field.name == name {
+				hidden = true
+				break
+			}
+		}
+		if !hidden && i < len(values) {
+			keptNames = append(keptNames, name)
+			keptValues = append(keptValues, values[i])
+		}
+	}
+
+	c.SetParamNames(keptNames...)
+	c.SetParamValues(keptValues...)
+	err := fn()
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	return err
+}
+
+// bindPathSlices splits delimited path parameters into their slice fields
+func bindPathSlices(c echo.Context, req reflect.Value, fields []pathSliceField) error {
+	for _, field := range fields {
+		raw := c.Param(field.name)
+		if raw == "" {
+			continue
+		}
+		parts := strings.Split(raw, field.delimiter)
+
+		target := req.Field(field.index)
+		slice := reflect.MakeSlice(target.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setScalar(slice.Index(i), part); err != nil {
+				return fmt.Errorf("%s[%d]: %v", field.name, i, err)
+			}
+		}
+		target.Set(slice)
+	}
+	return nil
+}
+
+// setScalar parses s into a value of a basic kind
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// pathParamSchema documents a path parameter from the matching `param`
+// field of the request type, falling back to a plain string
+func (app *App) pathParamSchema(param *openapi3.Parameter, requestType reflect.Type) {
+	param.Schema = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string"}}
+	if requestType == nil {
+		return
+	}
+	t := requestType
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("param") != param.Name {
+			continue
+		}
+		param.Schema = &openapi3.SchemaRef{Value: app.generateSchema(field.Type)}
+		if field.Type.Kind() == reflect.Slice {
+			// The simple style is comma-separated; other delimiters are noted as an extension
+			param.Style = openapi3.SerializationSimple
+			param.Explode = openapi3.BoolPtr(false)
+			if delimiter := pathDelimiter(field); delimiter != "," {
+				param.Extensions = map[string]interface{}{"x-delimiter": delimiter}
+			}
+		}
+		return
+	}
+}
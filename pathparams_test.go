@@ -0,0 +1,61 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayPathParams(t *testing.T) {
+	app := echonext.New()
+
+	type BulkGetRequest struct {
+		IDs []int `param:"ids" validate:"max=3,dive,min=1"`
+	}
+
+	app.GET("/items/:ids", func(c echo.Context, req BulkGetRequest) ([]int, error) {
+		return req.IDs, nil
+	})
+
+	doRequest := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("binds delimited values", func(t *testing.T) {
+		rec := doRequest("/items/1,2,3")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response echonext.Response[[]int]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, []int{1, 2, 3}, response.Data)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		rec := doRequest("/items/1,abc")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "ids[1]")
+	})
+
+	t.Run("elements validated", func(t *testing.T) {
+		rec := doRequest("/items/1,0")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Validation failed")
+	})
+
+	t.Run("documented as array", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		param := spec.Paths["/items/{ids}"].Get.Parameters[0].Value
+		assert.Equal(t, "ids", param.Name)
+		assert.Equal(t, "array", param.Schema.Value.Type)
+		assert.Equal(t, "integer", param.Schema.Value.Items.Value.Type)
+		assert.Equal(t, "simple", param.Style)
+	})
+}
@@ -0,0 +1,348 @@
+package echonext
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// postmanSchema identifies the collection format
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// Postman v2.1 collection format, limited to what is generated
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []*postmanItem    `json:"item"`
+	Variable []postmanVariable `json:"variable"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is a folder when Item is set and a request otherwise
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []*postmanItem  `json:"item,omitempty"`
+	Request *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string         `json:"method"`
+	Header      []postmanParam `json:"header"`
+	Body        *postmanBody   `json:"body,omitempty"`
+	URL         postmanURL     `json:"url"`
+	Description string         `json:"description,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string         `json:"raw"`
+	Host     []string       `json:"host"`
+	Path     []string       `json:"path"`
+	Query    []postmanParam `json:"query,omitempty"`
+	Variable []postmanParam `json:"variable,omitempty"`
+}
+
+type postmanParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string                 `json:"mode"`
+	Raw        string                 `json:"raw,omitempty"`
+	URLEncoded []postmanParam         `json:"urlencoded,omitempty"`
+	FormData   []postmanParam         `json:"formdata,omitempty"`
+	Options    map[string]interface{} `json:"options,omitempty"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GeneratePostmanCollection returns the served routes as a Postman v2.1
+// collection, with a folder per tag. Requests use the {{baseUrl}} variable,
+// defaulting to the first server; path params become :param variables,
+// query params are pre-filled and bodies hold examples built from the
+// request schema.
+func (app *App) GeneratePostmanCollection() ([]byte, error) {
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	spec := app.generateSpec()
+
+	baseURL := "http://localhost:8080"
+	if len(spec.Servers) > 0 {
+		baseURL = strings.TrimSuffix(spec.Servers[0].URL, "/")
+	}
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:        spec.Info.Title,
+			Description: spec.Info.Description,
+			Schema:      postmanSchema,
+		},
+		Item:     []*postmanItem{},
+		Variable: []postmanVariable{{Key: "baseUrl", Value: baseURL}},
+	}
+
+	folders := map[string]*postmanItem{}
+	for _, route := range app.routes {
+		if !route.isEnabled() {
+			continue
+		}
+		item, ok := spec.Paths[openAPIPath(route.Path)]
+		if !ok {
+			continue
+		}
+		operation := item.GetOperation(route.Method)
+		if operation == nil {
+			continue
+		}
+
+		request := &postmanItem{
+			Name:    postmanName(route, operation),
+			Request: postmanRequestFor(spec, route, operation),
+		}
+		if len(operation.Tags) == 0 {
+			collection.Item = append(collection.Item, request)
+			continue
+		}
+		tag := operation.Tags[0]
+		folder := folders[tag]
+		if folder == nil {
+			folder = &postmanItem{Name: tag}
+			folders[tag] = folder
+			collection.Item = append(collection.Item, folder)
+		}
+		folder.Item = append(folder.Item, request)
+	}
+
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+// ServePostmanCollection serves the Postman collection, generated per request
+// so it always matches the registered routes
+func (app *App) ServePostmanCollection(path string) {
+	app.Echo.GET(path, func(c echo.Context) error {
+		data, err := app.GeneratePostmanCollection()
+		if err != nil {
+			return errorResponse(c, http.StatusInternalServerError, fmt.Sprintf("Failed to generate Postman collection: %v", err))
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="collection.postman.json"`)
+		return c.JSONBlob(http.StatusOK, data)
+	})
+}
+
+func postmanName(route RouteInfo, operation *openapi3.Operation) string {
+	if operation.Summary != "" {
+		return operation.Summary
+	}
+	if operation.OperationID != "" {
+		return operation.OperationID
+	}
+	return route.Method + " " + route.Path
+}
+
+func postmanRequestFor(spec *openapi3.T, route RouteInfo, operation *openapi3.Operation) *postmanRequest {
+	segments := strings.Split(strings.Trim(route.Path, "/"), "/")
+	if len(segments) == 1 && segments[0] == "" {
+		segments = []string{}
+	}
+	request := &postmanRequest{
+		Method:      route.Method,
+		Header:      []postmanParam{},
+		Description: operation.Description,
+		URL: postmanURL{
+			Host: []string{"{{baseUrl}}"},
+			Path: segments,
+		},
+	}
+
+	var query []string
+	for _, ref := range operation.Parameters {
+		param := ref.Value
+		if param == nil {
+			continue
+		}
+		value := ""
+		if param.Example != nil {
+			value = fmt.Sprint(param.Example)
+		} else if param.Schema != nil {
+			if example := schemaExample(spec, param.Schema, map[string]bool{}); example != nil {
+				value = fmt.Sprint(example)
+			}
+		}
+		entry := postmanParam{Key: param.Name, Value: value, Description: param.Description}
+
+		switch param.In {
+		case "path":
+			entry.Value = ""
+			request.URL.Variable = append(request.URL.Variable, entry)
+		case "query":
+			entry.Disabled = !param.Required
+			request.URL.Query = append(request.URL.Query, entry)
+			if !entry.Disabled {
+				query = append(query, param.Name+"="+value)
+			}
+		case "header":
+			entry.Disabled = !param.Required
+			request.Header = append(request.Header, entry)
+		}
+	}
+
+	request.URL.Raw = "{{baseUrl}}" + route.Path
+	if len(query) > 0 {
+		request.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		request.Body = postmanBodyFor(spec, operation.RequestBody.Value.Content, request)
+	}
+	return request
+}
+
+// postmanBodyFor builds an example body, preferring JSON among the accepted
+// content types, and sets its Content-Type header
+func postmanBodyFor(spec *openapi3.T, content openapi3.Content, request *postmanRequest) *postmanBody {
+	contentType := echo.MIMEApplicationJSON
+	if content[contentType] == nil {
+		types := sortedMediaTypes(content)
+		if len(types) == 0 {
+			return nil
+		}
+		contentType = types[0]
+	}
+	mediaType := content[contentType]
+
+	var example interface{}
+	switch {
+	case mediaType.Example != nil:
+		example = mediaType.Example
+	case len(mediaType.Examples) > 0:
+		names := make([]string, 0, len(mediaType.Examples))
+		for name := range mediaType.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if ref := mediaType.Examples[names[0]]; ref.Value != nil {
+			example = ref.Value.Value
+		}
+	case mediaType.Schema != nil:
+		example = schemaExample(spec, mediaType.Schema, map[string]bool{})
+	}
+
+	if contentType == echo.MIMEApplicationForm || contentType == echo.MIMEMultipartForm {
+		return postmanFormBody(spec, contentType, mediaType.Schema, example)
+	}
+
+	request.Header = append(request.Header, postmanParam{Key: echo.HeaderContentType, Value: contentType})
+	raw, err := json.MarshalIndent(example, "", "  ")
+	if err != nil || example == nil {
+		raw = []byte("{}")
+	}
+	return &postmanBody{
+		Mode:    "raw",
+		Raw:     string(raw),
+		Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+	}
+}
+
+// postmanFormBody lists form fields with their example values; Postman sets
+// the Content-Type of form bodies itself
+func postmanFormBody(spec *openapi3.T, contentType string, ref *openapi3.SchemaRef, example interface{}) *postmanBody {
+	schema := resolveSchema(spec, ref)
+	values, _ := example.(map[string]interface{})
+	var fields []postmanParam
+	if schema != nil {
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := postmanParam{Key: name, Type: "text"}
+			if property := resolveSchema(spec, schema.Properties[name]); property != nil && property.Format == "binary" {
+				field.Type = "file"
+			} else if value, ok := values[name]; ok && value != nil {
+				field.Value = fmt.Sprint(value)
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	if contentType == echo.MIMEMultipartForm {
+		return &postmanBody{Mode: "formdata", FormData: fields}
+	}
+	for i := range fields {
+		fields[i].Type = ""
+	}
+	return &postmanBody{Mode: "urlencoded", URLEncoded: fields}
+}
+
+// resolveSchema follows a component reference
+func resolveSchema(spec *openapi3.T, ref *openapi3.SchemaRef) *openapi3.Schema {
+	if ref == nil {
+		return nil
+	}
+	if ref.Value == nil && strings.HasPrefix(ref.Ref, "#/components/schemas/") && spec.Components != nil {
+		return resolveSchema(spec, spec.Components.Schemas[strings.TrimPrefix(ref.Ref, "#/components/schemas/")])
+	}
+	return ref.Value
+}
+
+// schemaExample builds a representative value for a schema from its example
+// or default, falling back to a placeholder for its type.
+// seen stops recursion through self-referencing components.
+func schemaExample(spec *openapi3.T, ref *openapi3.SchemaRef, seen map[string]bool) interface{} {
+	if ref.Ref != "" {
+		if seen[ref.Ref] {
+			return nil
+		}
+		seen[ref.Ref] = true
+		defer delete(seen, ref.Ref)
+	}
+	schema := resolveSchema(spec, ref)
+	if schema == nil {
+		return nil
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	}
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		if len(group) > 0 {
+			return schemaExample(spec, group[0], seen)
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		object := map[string]interface{}{}
+		for name, property := range schema.Properties {
+			if value := resolveSchema(spec, property); value != nil && value.ReadOnly {
+				continue
+			}
+			object[name] = schemaExample(spec, property, seen)
+		}
+		return object
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{schemaExample(spec, schema.Items, seen)}
+	}
+	return typeExample(schema)
+}
@@ -0,0 +1,104 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ListUsersQuery struct {
+	Role  string `query:"role" validate:"required"`
+	Limit int    `query:"limit"`
+}
+
+func TestPostmanCollection(t *testing.T) {
+	app := echonext.New()
+	app.SetInfo("Users API", "1.0.0", "Manage users")
+	app.SetServers([]echonext.Server{{URL: "https://api.example.com/"}})
+	app.GET("/users", func(c echo.Context, q ListUsersQuery) ([]TestUser, error) { return nil, nil }, echonext.Route{
+		Summary: "List users",
+		Tags:    []string{"users"},
+	})
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) { return TestUser{}, nil }, echonext.Route{
+		Summary: "Create user",
+		Tags:    []string{"users"},
+	})
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) { return TestUser{}, nil }, echonext.Route{
+		Tags: []string{"users"},
+	})
+	app.POST("/uploads", func(c echo.Context, req UploadRequest) (UploadResponse, error) { return UploadResponse{}, nil })
+
+	data, err := app.GeneratePostmanCollection()
+	require.NoError(t, err)
+
+	var collection struct {
+		Info     map[string]string   `json:"info"`
+		Variable []map[string]string `json:"variable"`
+		Item     []struct {
+			Name    string                 `json:"name"`
+			Request map[string]interface{} `json:"request"`
+			Item    []struct {
+				Name    string `json:"name"`
+				Request struct {
+					Method string `json:"method"`
+					Header []map[string]interface{}
+					Body   map[string]interface{} `json:"body"`
+					URL    struct {
+						Raw      string                   `json:"raw"`
+						Path     []string                 `json:"path"`
+						Query    []map[string]interface{} `json:"query"`
+						Variable []map[string]interface{} `json:"variable"`
+					} `json:"url"`
+				} `json:"request"`
+			} `json:"item"`
+		} `json:"item"`
+	}
+	require.NoError(t, json.Unmarshal(data, &collection))
+
+	assert.Equal(t, "Users API", collection.Info["name"])
+	assert.Equal(t, "https://schema.getpostman.com/json/collection/v2.1.0/collection.json", collection.Info["schema"])
+	assert.Equal(t, []map[string]string{{"key": "baseUrl", "value": "https://api.example.com"}}, collection.Variable)
+
+	require.Len(t, collection.Item, 2)
+	users := collection.Item[0]
+	assert.Equal(t, "users", users.Name)
+	require.Len(t, users.Item, 3)
+
+	list := users.Item[0]
+	assert.Equal(t, "List users", list.Name)
+	assert.Equal(t, "{{baseUrl}}/users?role=string", list.Request.URL.Raw)
+	assert.Equal(t, []map[string]interface{}{
+		{"key": "role", "value": "string"},
+		{"key": "limit", "value": "0", "disabled": true},
+	}, list.Request.URL.Query)
+
+	create := users.Item[1]
+	assert.Equal(t, "POST", create.Request.Method)
+	assert.Equal(t, "raw", create.Request.Body["mode"])
+	assert.JSONEq(t, `{"name":"string","email":"user@example.com"}`, create.Request.Body["raw"].(string))
+	assert.Contains(t, create.Request.Header, map[string]interface{}{"key": "Content-Type", "value": "application/json"})
+
+	get := users.Item[2]
+	assert.Equal(t, "{{baseUrl}}/users/:id", get.Request.URL.Raw)
+	assert.Equal(t, []string{"users", ":id"}, get.Request.URL.Path)
+	assert.Equal(t, []map[string]interface{}{{"key": "id", "value": ""}}, get.Request.URL.Variable)
+
+	upload := collection.Item[1]
+	assert.Equal(t, "formdata", upload.Request["body"].(map[string]interface{})["mode"])
+	assert.Contains(t, upload.Request["body"].(map[string]interface{})["formdata"], map[string]interface{}{"key": "avatar", "value": "", "type": "file"})
+
+	t.Run("served", func(t *testing.T) {
+		app.ServePostmanCollection("/postman.json")
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/postman.json", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+		assert.JSONEq(t, string(data), rec.Body.String())
+	})
+}
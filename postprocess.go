@@ -0,0 +1,13 @@
+package echonext
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// AddSpecPostProcessor registers fn to customize the spec after it is
+// generated and before it is served. Processors run in registration order
+// each time the spec is generated, so they should be idempotent.
+func (app *App) AddSpecPostProcessor(fn func(spec *openapi3.T)) {
+	if fn == nil {
+		panic("echonext: nil spec post-processor")
+	}
+	app.specProcessors = append(app.specProcessors, fn)
+}
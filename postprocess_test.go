@@ -0,0 +1,50 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecPostProcessors(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return nil, nil
+	}, echonext.Route{Summary: "List users", Description: "Internal notes"})
+
+	var order []string
+	app.AddSpecPostProcessor(func(spec *openapi3.T) {
+		order = append(order, "owner")
+		if spec.Extensions == nil {
+			spec.Extensions = map[string]interface{}{}
+		}
+		spec.Extensions["x-owner"] = "platform-team"
+	})
+	app.AddSpecPostProcessor(func(spec *openapi3.T) {
+		order = append(order, "strip")
+		for _, item := range spec.Paths {
+			for _, operation := range item.Operations() {
+				operation.Description = ""
+			}
+		}
+	})
+	app.ServeOpenAPISpec("/openapi.json")
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"owner", "strip"}, order)
+
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "platform-team", doc["x-owner"])
+	assert.NotContains(t, rec.Body.String(), "Internal notes")
+
+	assert.Panics(t, func() { app.AddSpecPostProcessor(nil) })
+}
@@ -0,0 +1,58 @@
+package echonext
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// queryDefault is the value of a query parameter the request omits
+type queryDefault struct {
+	param queryParam
+	vals  []string
+}
+
+// queryDefaults returns the defaults that `default` tags declare for the
+// query parameters of t. Slices take comma-separated values. Defaults that
+// don't parse as their field panic.
+func queryDefaults(t reflect.Type) []queryDefault {
+	var defaults []queryDefault
+	for _, param := range queryParams(t) {
+		tag, ok := param.field.Tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		vals := []string{tag}
+		if param.field.Type.Kind() == reflect.Slice {
+			vals = strings.Split(tag, ",")
+			for i := range vals {
+				vals[i] = strings.TrimSpace(vals[i])
+			}
+		}
+
+		// Catch typos at registration rather than on the first request
+		scratch := reflect.New(param.field.Type).Elem()
+		if err := setQueryValue(allocElem(scratch), vals); err != nil {
+			panic(fmt.Sprintf("echonext: default %q of query parameter %s: %v", tag, param.name, err))
+		}
+		defaults = append(defaults, queryDefault{param: param, vals: vals})
+	}
+	return defaults
+}
+
+// applyQueryDefaults sets the query parameters that are missing or empty in
+// query to their defaults, so handlers can tell them from an explicit zero
+func applyQueryDefaults(query url.Values, target reflect.Value, defaults []queryDefault) {
+	for _, d := range defaults {
+		if vals := query[d.param.name]; len(vals) > 0 && vals[0] != "" {
+			continue
+		}
+		field := allocElem(target)
+		for _, i := range d.param.index {
+			field = allocElem(field.Field(i))
+		}
+		// Parsed at registration, so this can't fail
+		_ = setQueryValue(field, d.vals)
+	}
+}
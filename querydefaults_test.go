@@ -0,0 +1,101 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type ListPagedRequest struct {
+	Page    int      `query:"page" default:"1" validate:"min=1"`
+	Limit   int      `query:"limit" default:"10" validate:"min=1,max=100"`
+	Ratio   float64  `query:"ratio" default:"0.5"`
+	Archive bool     `query:"archive" default:"true"`
+	Sort    string   `query:"sort" default:"name"`
+	Status  []string `query:"status" default:"open, done"`
+	Filter  struct {
+		Owner string `query:"owner" default:"me"`
+	} `query:"filter"`
+}
+
+func TestQueryDefaults(t *testing.T) {
+	app := echonext.New()
+
+	var got ListPagedRequest
+	app.GET("/items", func(c echo.Context, req ListPagedRequest) ([]string, error) {
+		got = req
+		return []string{}, nil
+	})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		got = ListPagedRequest{}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items?"+query, nil))
+		return rec
+	}
+
+	t.Run("omitted", func(t *testing.T) {
+		rec := get("")
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 1, got.Page)
+		assert.Equal(t, 10, got.Limit)
+		assert.Equal(t, 0.5, got.Ratio)
+		assert.True(t, got.Archive)
+		assert.Equal(t, "name", got.Sort)
+		assert.Equal(t, []string{"open", "done"}, got.Status)
+		assert.Equal(t, "me", got.Filter.Owner)
+	})
+
+	t.Run("empty values use the default", func(t *testing.T) {
+		rec := get("page=&sort=")
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 1, got.Page)
+		assert.Equal(t, "name", got.Sort)
+	})
+
+	t.Run("explicit values win", func(t *testing.T) {
+		rec := get("page=3&limit=50&archive=false&status=open&filter.owner=ann")
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 3, got.Page)
+		assert.Equal(t, 50, got.Limit)
+		assert.False(t, got.Archive)
+		assert.Equal(t, []string{"open"}, got.Status)
+		assert.Equal(t, "ann", got.Filter.Owner)
+	})
+
+	t.Run("explicit values are still validated", func(t *testing.T) {
+		rec := get("page=0")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		defaults := map[string]interface{}{}
+		for _, param := range spec.Paths["/items"].Get.Parameters {
+			defaults[param.Value.Name] = param.Value.Schema.Value.Default
+			assert.False(t, param.Value.Required, param.Value.Name)
+		}
+		assert.Equal(t, map[string]interface{}{
+			"page":         int64(1),
+			"limit":        int64(10),
+			"ratio":        0.5,
+			"archive":      true,
+			"sort":         "name",
+			"status":       []interface{}{"open", "done"},
+			"filter.owner": "me",
+		}, defaults)
+	})
+
+	t.Run("invalid default panics", func(t *testing.T) {
+		type BadDefault struct {
+			Limit int `query:"limit" default:"ten"`
+		}
+		assert.PanicsWithValue(t, `echonext: default "ten" of query parameter limit: strconv.ParseInt: parsing "ten": invalid syntax`, func() {
+			echonext.New().GET("/bad", func(c echo.Context, req BadDefault) ([]string, error) { return nil, nil })
+		})
+	})
+}
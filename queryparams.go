@@ -0,0 +1,149 @@
+package echonext
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	bindUnmarshalerType = reflect.TypeOf((*echo.BindUnmarshaler)(nil)).Elem()
+)
+
+// queryParam is a query parameter bound into a possibly nested request field
+type queryParam struct {
+	name   string
+	index  []int // field indexes from the request struct, through nested structs
+	field  reflect.StructField
+	nested bool // named by a parent's tag, so Echo's binder doesn't see it
+	inPtr  bool // inside an optional struct, so never required by itself
+}
+
+// queryParams returns the query parameters of request type t. Struct fields
+// tagged `query:"filter"` are flattened into "filter.status" style names, and
+// untagged or embedded structs contribute their fields unprefixed, as in
+// Echo's binder. Slices of structs aren't flattened.
+func queryParams(t reflect.Type) []queryParam {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return collectQueryParams(t, queryParam{}, map[reflect.Type]bool{t: true})
+}
+
+// collectQueryParams gathers the parameters of struct t under parent, whose
+// name is used as a prefix
+func collectQueryParams(t reflect.Type, parent queryParam, seen map[reflect.Type]bool) []queryParam {
+	var params []queryParam
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("query")
+		if tag == "-" {
+			continue
+		}
+		param := queryParam{
+			name:   parent.name,
+			index:  append(append([]int(nil), parent.index...), i),
+			field:  field,
+			nested: parent.nested,
+			inPtr:  parent.inPtr,
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && !isQueryLeaf(fieldType) {
+			// Stop at types that contain themselves
+			if seen[fieldType] {
+				continue
+			}
+			seen[fieldType] = true
+			if tag != "" {
+				param.name += tag + "."
+				param.nested = true
+			}
+			param.inPtr = param.inPtr || field.Type.Kind() == reflect.Ptr
+			params = append(params, collectQueryParams(fieldType, param, seen)...)
+			delete(seen, fieldType)
+			continue
+		}
+
+		if tag == "" || isStructSlice(fieldType) {
+			continue
+		}
+		param.name += tag
+		params = append(params, param)
+	}
+	return params
+}
+
+// isQueryLeaf reports whether struct type t is bound from a single value
+func isQueryLeaf(t reflect.Type) bool {
+	return t == timeType || reflect.PtrTo(t).Implements(textUnmarshalerType) || reflect.PtrTo(t).Implements(bindUnmarshalerType)
+}
+
+// isStructSlice reports whether t is a slice of structs that aren't leaves
+func isStructSlice(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	elem := t.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct && !isQueryLeaf(elem)
+}
+
+// bindNestedQuery binds the query parameters named by a parent struct's tag
+func bindNestedQuery(query url.Values, target reflect.Value, params []queryParam) error {
+	for _, param := range params {
+		vals := query[param.name]
+		if !param.nested || len(vals) == 0 {
+			continue
+		}
+		field := allocElem(target)
+		for _, i := range param.index {
+			field = allocElem(field.Field(i))
+		}
+		if err := setQueryValue(field, vals); err != nil {
+			return fmt.Errorf("%s: %w", param.name, err)
+		}
+	}
+	return nil
+}
+
+// setQueryValue parses vals into field, which is a scalar, a text
+// unmarshaler or a slice of either
+func setQueryValue(field reflect.Value, vals []string) error {
+	if field.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := setQueryValue(allocElem(slice.Index(i)), []string{val}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	switch target := field.Addr().Interface().(type) {
+	case echo.BindUnmarshaler:
+		return target.UnmarshalParam(vals[0])
+	case encoding.TextUnmarshaler:
+		return target.UnmarshalText([]byte(vals[0]))
+	}
+	return setScalar(field, vals[0])
+}
@@ -0,0 +1,113 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type DateRange struct {
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+type Paging struct {
+	Page int `query:"page" validate:"omitempty,min=1"`
+}
+
+type TodoFilter struct {
+	Status []string  `query:"status" validate:"dive,oneof=open done"`
+	Range  DateRange `query:"range"`
+	Owner  *struct {
+		ID string `query:"id" validate:"required"`
+	} `query:"owner"`
+	Tags []struct {
+		Name string `query:"name"`
+	} `query:"tags"`
+}
+
+type SearchTodosRequest struct {
+	Paging
+	Filter TodoFilter `query:"filter"`
+	Q      string     `query:"q"`
+}
+
+func TestNestedQueryParameters(t *testing.T) {
+	app := echonext.New()
+
+	var got SearchTodosRequest
+	app.GET("/todos", func(c echo.Context, req SearchTodosRequest) ([]Todo, error) {
+		got = req
+		return []Todo{}, nil
+	})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		got = SearchTodosRequest{}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos?"+query, nil))
+		return rec
+	}
+
+	t.Run("binding", func(t *testing.T) {
+		rec := get("q=milk&page=2&filter.status=open&filter.status=done&filter.range.from=2024-01-01T00:00:00Z&filter.owner.id=42")
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "milk", got.Q)
+		assert.Equal(t, 2, got.Page)
+		assert.Equal(t, []string{"open", "done"}, got.Filter.Status)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), got.Filter.Range.From)
+		assert.True(t, got.Filter.Range.To.IsZero())
+		if assert.NotNil(t, got.Filter.Owner) {
+			assert.Equal(t, "42", got.Filter.Owner.ID)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		rec := get("filter.status=archived&page=0")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var invalid echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &invalid))
+		if assert.Len(t, invalid.Details, 1) {
+			assert.Equal(t, "filter.status[0]", invalid.Details[0].Field)
+			assert.Equal(t, "query", invalid.Details[0].In)
+		}
+
+		rec = get("page=-1")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		invalid = echonext.Response[any]{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &invalid))
+		if assert.Len(t, invalid.Details, 1) {
+			assert.Equal(t, "page", invalid.Details[0].Field)
+			assert.Equal(t, "query", invalid.Details[0].In)
+		}
+
+		rec = get("filter.range.from=yesterday")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Contains(t, response.Error, "filter.range.from")
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		params := map[string]string{}
+		for _, param := range spec.Paths["/todos"].Get.Parameters {
+			params[param.Value.Name] = param.Value.Schema.Value.Type
+			// Owner is optional, so its required ID is only required alongside it
+			assert.False(t, param.Value.Required, param.Value.Name)
+		}
+		assert.Equal(t, map[string]string{
+			"page":              "integer",
+			"filter.status":     "array",
+			"filter.range.from": "string",
+			"filter.range.to":   "string",
+			"filter.owner.id":   "string",
+			"q":                 "string",
+		}, params)
+	})
+}
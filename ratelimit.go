@@ -0,0 +1,131 @@
+package echonext
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// Rate limit response headers
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimit configures a fixed-window request limit for a route
+type RateLimit struct {
+	Requests int                         // Maximum requests per window
+	Window   time.Duration               // Window length, defaults to one minute
+	KeyFunc  func(c echo.Context) string // Client key, defaults to the real IP
+}
+
+// rateLimiter tracks request counts per client key
+type rateLimiter struct {
+	config    RateLimit
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// checkRateLimit panics on a limit that would reject every request
+func checkRateLimit(limit *RateLimit) {
+	if limit != nil && limit.Requests <= 0 {
+		panic(fmt.Sprintf("echonext: rate limit must allow at least one request, got %d", limit.Requests))
+	}
+}
+
+func newRateLimiter(config RateLimit) *rateLimiter {
+	if config.Window <= 0 {
+		config.Window = time.Minute
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(c echo.Context) string {
+			return c.RealIP()
+		}
+	}
+	return &rateLimiter{
+		config:  config,
+		windows: make(map[string]*rateWindow),
+	}
+}
+
+// allow records a request for key and reports whether it is within the limit,
+// along with the remaining requests and the time the current window resets
+func (rl *rateLimiter) allow(key string, now time.Time) (bool, int, time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Drop expired windows so idle clients don't accumulate
+	if now.Sub(rl.lastSweep) >= rl.config.Window {
+		for k, w := range rl.windows {
+			if now.Sub(w.start) >= rl.config.Window {
+				delete(rl.windows, k)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	w, ok := rl.windows[key]
+	if !ok || now.Sub(w.start) >= rl.config.Window {
+		w = &rateWindow{start: now}
+		rl.windows[key] = w
+	}
+
+	reset := w.start.Add(rl.config.Window)
+	if w.count >= rl.config.Requests {
+		return false, 0, reset
+	}
+	w.count++
+	return true, rl.config.Requests - w.count, reset
+}
+
+// middleware sets rate limit headers on every response and rejects requests
+// over the limit with a 429 envelope
+func (rl *rateLimiter) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		allowed, remaining, reset := rl.allow(rl.config.KeyFunc(c), time.Now())
+
+		header := c.Response().Header()
+		header.Set(HeaderRateLimitLimit, strconv.Itoa(rl.config.Requests))
+		header.Set(HeaderRateLimitRemaining, strconv.Itoa(remaining))
+		header.Set(HeaderRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+
+		if !allowed {
+			header.Set("Retry-After", strconv.Itoa(int(time.Until(reset).Seconds())+1))
+			return errorResponse(c, http.StatusTooManyRequests, "Rate limit exceeded")
+		}
+		return next(c)
+	}
+}
+
+// rateLimitHeaders returns the OpenAPI headers documenting rate limit state
+func rateLimitHeaders() openapi3.Headers {
+	header := func(description string) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{
+			Value: &openapi3.Header{
+				Parameter: openapi3.Parameter{
+					Description: description,
+					Schema: &openapi3.SchemaRef{
+						Value: &openapi3.Schema{Type: "integer"},
+					},
+				},
+			},
+		}
+	}
+	return openapi3.Headers{
+		HeaderRateLimitLimit:     header("Maximum requests allowed in the current window"),
+		HeaderRateLimitRemaining: header("Requests remaining in the current window"),
+		HeaderRateLimitReset:     header("Unix time at which the current window resets"),
+	}
+}
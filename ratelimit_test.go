@@ -0,0 +1,64 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	app := echonext.New()
+
+	app.GET("/limited", func(c echo.Context) (TestUser, error) {
+		return TestUser{ID: "1", Name: "John"}, nil
+	}, echonext.Route{
+		RateLimit: &echonext.RateLimit{Requests: 2, Window: time.Minute},
+	})
+
+	doRequest := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("successful requests carry headers", func(t *testing.T) {
+		rec := doRequest()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(echonext.HeaderRateLimitLimit))
+		assert.Equal(t, "1", rec.Header().Get(echonext.HeaderRateLimitRemaining))
+		assert.NotEmpty(t, rec.Header().Get(echonext.HeaderRateLimitReset))
+
+		rec = doRequest()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "0", rec.Header().Get(echonext.HeaderRateLimitRemaining))
+	})
+
+	t.Run("exceeded limit", func(t *testing.T) {
+		rec := doRequest()
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(echonext.HeaderRateLimitLimit))
+		assert.Equal(t, "0", rec.Header().Get(echonext.HeaderRateLimitRemaining))
+		assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("documented in spec", func(t *testing.T) {
+		op := app.GenerateOpenAPISpec().Paths["/limited"].Get
+		assert.Contains(t, op.Responses["200"].Value.Headers, echonext.HeaderRateLimitRemaining)
+		assert.Contains(t, op.Responses, "429")
+	})
+}
+
+func TestRateLimitRejectsEmptyLimit(t *testing.T) {
+	app := echonext.New()
+	assert.PanicsWithValue(t, "echonext: rate limit must allow at least one request, got 0", func() {
+		app.GET("/limited", func(c echo.Context) (TestUser, error) {
+			return TestUser{}, nil
+		}, echonext.Route{RateLimit: &echonext.RateLimit{Window: time.Minute}})
+	})
+}
@@ -0,0 +1,42 @@
+package echonext
+
+import (
+	"encoding/xml"
+	"mime"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderRawResponse asks for bare data instead of the response envelope when "true"
+const HeaderRawResponse = "X-Raw-Response"
+
+// RawProfile is the Accept profile that also selects bare responses:
+// Accept: application/json; profile="raw"
+const RawProfile = "raw"
+
+// rawError is the error body sent to callers that opted out of the envelope
+type rawError struct {
+	XMLName       xml.Name     `json:"-" xml:"error"`
+	Error         string       `json:"error" xml:"message"`
+	CorrelationID string       `json:"correlation_id,omitempty" xml:"correlation_id,omitempty"`
+	Details       []FieldError `json:"details,omitempty" xml:"detail,omitempty"`
+}
+
+// wantsRaw reports whether the caller opted out of the response envelope
+func wantsRaw(c echo.Context) bool {
+	req := c.Request()
+	if strings.EqualFold(req.Header.Get(HeaderRawResponse), "true") {
+		return true
+	}
+	header := req.Header.Get(echo.HeaderAccept)
+	if !strings.Contains(header, "profile") {
+		return false
+	}
+	for _, accept := range strings.Split(header, ",") {
+		if _, params, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && params["profile"] == RawProfile {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawResponse(t *testing.T) {
+	app := echonext.New()
+	app.GET("/users/:id", func(c echo.Context) (TestUser, error) {
+		if c.Param("id") != "1" {
+			return TestUser{}, echo.NewHTTPError(http.StatusNotFound, "User not found")
+		}
+		return TestUser{ID: "1", Name: "John", Email: "john@example.com"}, nil
+	})
+
+	serve := func(path string, header http.Header) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+
+	user := `{"id":"1","name":"John","email":"john@example.com"}`
+
+	assert.JSONEq(t, `{"data":`+user+`,"success":true}`, serve("/users/1", nil))
+	assert.JSONEq(t, user, serve("/users/1", http.Header{echonext.HeaderRawResponse: {"true"}}))
+	assert.JSONEq(t, user, serve("/users/1", http.Header{echo.HeaderAccept: {`application/json; profile="raw"`}}))
+	assert.JSONEq(t, `{"data":`+user+`,"success":true}`, serve("/users/1", http.Header{echonext.HeaderRawResponse: {"false"}}))
+
+	t.Run("errors", func(t *testing.T) {
+		assert.JSONEq(t, `{"error":"User not found","success":false}`, serve("/users/2", nil))
+		assert.JSONEq(t, `{"error":"User not found"}`, serve("/users/2", http.Header{echonext.HeaderRawResponse: {"true"}}))
+	})
+}
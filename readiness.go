@@ -0,0 +1,41 @@
+package echonext
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UseReadinessGate rejects requests with 503 until MarkReady is called.
+// Paths in allowlist (route templates like "/health" or exact request paths)
+// are always served so probes keep working while the app warms up.
+func (app *App) UseReadinessGate(allowlist []string) {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, path := range allowlist {
+		allowed[path] = true
+	}
+
+	app.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if app.ready.Load() || allowed[c.Path()] || allowed[c.Request().URL.Path] {
+				return next(c)
+			}
+			return errorResponse(c, http.StatusServiceUnavailable, "Service not ready")
+		}
+	})
+}
+
+// MarkReady opens the readiness gate so all traffic is served
+func (app *App) MarkReady() {
+	app.ready.Store(true)
+}
+
+// MarkNotReady closes the readiness gate, e.g. while draining before shutdown
+func (app *App) MarkNotReady() {
+	app.ready.Store(false)
+}
+
+// IsReady reports whether the readiness gate is open
+func (app *App) IsReady() bool {
+	return app.ready.Load()
+}
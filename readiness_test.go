@@ -0,0 +1,40 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessGate(t *testing.T) {
+	app := echonext.New()
+	app.UseReadinessGate([]string{"/health"})
+
+	app.GET("/health", func(c echo.Context) (map[string]string, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return []TestUser{{ID: "1"}}, nil
+	})
+
+	doRequest := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.False(t, app.IsReady())
+	assert.Equal(t, http.StatusServiceUnavailable, doRequest("/users"))
+	assert.Equal(t, http.StatusOK, doRequest("/health"))
+
+	app.MarkReady()
+	assert.Equal(t, http.StatusOK, doRequest("/users"))
+
+	app.MarkNotReady()
+	assert.Equal(t, http.StatusServiceUnavailable, doRequest("/users"))
+}
@@ -0,0 +1,50 @@
+package echonext
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetRecoverPanics makes typed routes recover panics in their handlers and
+// respond with a 500 error envelope, logging the panic with its stack. The
+// panic value is only sent when app.Debug is set. Leave it off when Echo's
+// Recover middleware already handles panics.
+func (app *App) SetRecoverPanics(enabled bool) {
+	app.recoverPanics = enabled
+}
+
+// recoverPanic turns a panic in next into an error response when panics are
+// recovered. Aborted handlers are left to the server.
+func (app *App) recoverPanic(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) (err error) {
+		if !app.recoverPanics {
+			return next(c)
+		}
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			req := c.Request()
+			app.Logger.Errorf("echonext: panic in %s %s: %v\n%s", req.Method, req.URL.Path, recovered, debug.Stack())
+			if c.Response().Committed {
+				// Too late for an error response; the client sees a cut-off body
+				err = nil
+				return
+			}
+			message := "internal error"
+			if app.Debug {
+				message = fmt.Sprintf("internal error: %v", recovered)
+			}
+			err = errorResponse(c, http.StatusInternalServerError, message)
+		}()
+		return next(c)
+	}
+}
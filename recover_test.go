@@ -0,0 +1,62 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPanics(t *testing.T) {
+	newApp := func() *echonext.App {
+		app := echonext.New()
+		app.GET("/boom", func(c echo.Context) (TestUser, error) {
+			panic("nil map write")
+		})
+		return app
+	}
+	get := func(app *echonext.App) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/boom", nil))
+		return rec
+	}
+
+	t.Run("recovered", func(t *testing.T) {
+		app := newApp()
+		var logs bytes.Buffer
+		app.Logger.SetOutput(&logs)
+		app.SetRecoverPanics(true)
+
+		rec := get(app)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var response echonext.Response[any]
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.False(t, response.Success)
+		assert.Equal(t, "internal error", response.Error)
+
+		assert.Contains(t, logs.String(), "panic in GET /boom: nil map write")
+		assert.Contains(t, logs.String(), "goroutine")
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		app := newApp()
+		app.Logger.SetOutput(&bytes.Buffer{})
+		app.SetRecoverPanics(true)
+		app.Debug = true
+
+		rec := get(app)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "internal error: nil map write")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		app := newApp()
+		assert.Panics(t, func() { get(app) }, "panics are left to Echo's Recover middleware")
+	})
+}
@@ -0,0 +1,122 @@
+package echonext
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the values of fields tagged `sensitive:"true"` or
+// with the redact JSON option
+const RedactedValue = "***"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// isSensitive reports whether a struct field holds secrets that must not be
+// echoed in logs or error messages, marked by `sensitive:"true"` or a redact
+// option such as `json:"password,redact"`, which encoding/json ignores
+func isSensitive(field reflect.StructField) bool {
+	if field.Tag.Get("sensitive") == "true" {
+		return true
+	}
+	options := strings.Split(field.Tag.Get("json"), ",")
+	for _, option := range options[1:] {
+		if option == "redact" {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of v suitable for logging, shaped like its JSON
+// encoding, with every sensitive field replaced by "***".
+// Nested structs, slices and maps are walked recursively.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v))
+}
+
+func redactValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	// Types with custom encodings (time.Time, etc.) are kept as-is
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, v.NumField())
+		redactFields(v, out)
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+func redactFields(v reflect.Value, out map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		value := v.Field(i)
+
+		// Embedded structs without a JSON name are flattened into the parent
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := value
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				redactFields(embedded, out)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if isSensitive(field) {
+			out[name] = RedactedValue
+			continue
+		}
+		out[name] = redactValue(value)
+	}
+}
@@ -0,0 +1,108 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password" sensitive:"true"`
+}
+
+type SignupRequest struct {
+	Credentials
+	Profile struct {
+		Name   string `json:"name"`
+		APIKey string `json:"api_key" sensitive:"true"`
+	} `json:"profile"`
+	Tokens    []Credentials `json:"tokens"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func TestRedact(t *testing.T) {
+	req := SignupRequest{
+		Credentials: Credentials{Username: "alice", Password: "hunter2"},
+		Tokens:      []Credentials{{Username: "bot", Password: "s3cret"}},
+	}
+	req.Profile.Name = "Alice"
+	req.Profile.APIKey = "key-123"
+
+	logged, err := json.Marshal(echonext.Redact(req))
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(logged), `"username":"alice"`)
+	assert.Contains(t, string(logged), `"password":"***"`)
+	assert.Contains(t, string(logged), `"api_key":"***"`)
+	assert.NotContains(t, string(logged), "hunter2")
+	assert.NotContains(t, string(logged), "s3cret")
+	assert.NotContains(t, string(logged), "key-123")
+}
+
+func TestSensitiveQueryErrorRedacted(t *testing.T) {
+	app := echonext.New()
+
+	type LookupRequest struct {
+		PIN int `query:"pin" sensitive:"true"`
+	}
+
+	app.GET("/lookup", func(c echo.Context, req LookupRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/lookup?pin=12ab", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "12ab")
+
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, echonext.RedactedValue, response.Details[0].Value)
+}
+
+func TestSensitiveBodyErrorRedacted(t *testing.T) {
+	app := echonext.New()
+
+	type Card struct {
+		PIN int8 `json:"pin" sensitive:"true"`
+	}
+	type PaymentRequest struct {
+		Card  Card `json:"card"`
+		Items int8 `json:"items"`
+	}
+
+	app.POST("/payments", func(c echo.Context, req PaymentRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+
+	post := func(body string) echonext.Response[any] {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "1234")
+
+		var response echonext.Response[any]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return response
+	}
+
+	response := post(`{"card":{"pin":1234}}`)
+	assert.Equal(t, "card.pin", response.Details[0].Field)
+	assert.Equal(t, echonext.RedactedValue, response.Details[0].Value)
+
+	// Other fields still show the value
+	response = post(`{"items":300}`)
+	assert.Equal(t, "300", response.Details[0].Value)
+}
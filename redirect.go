@@ -0,0 +1,51 @@
+package echonext
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// Redirect is a handler result that sends a 3xx redirect instead of an
+// enveloped response. Status defaults to 302 Found; other statuses outside
+// 3xx are sent as a 500 error.
+type Redirect struct {
+	Status int
+	URL    string
+}
+
+var redirectType = reflect.TypeOf(Redirect{})
+
+// statusCode returns the redirect status, defaulting to 302
+func (r Redirect) statusCode() int {
+	if r.Status == 0 {
+		return http.StatusFound
+	}
+	return r.Status
+}
+
+// redirectResponse documents a redirect with its Location header
+func redirectResponse(route RouteInfo) (string, *openapi3.Response) {
+	status := http.StatusFound
+	if route.RouteConfig != nil && route.RouteConfig.SuccessStatus >= 300 && route.RouteConfig.SuccessStatus < 400 {
+		status = route.RouteConfig.SuccessStatus
+	}
+	return strconv.Itoa(status), &openapi3.Response{
+		Description: strPtr(http.StatusText(status)),
+		Headers: openapi3.Headers{
+			echo.HeaderLocation: &openapi3.HeaderRef{
+				Value: &openapi3.Header{
+					Parameter: openapi3.Parameter{
+						Description: "Redirect target URL",
+						Schema: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{Type: "string", Format: "uri"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
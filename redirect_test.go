@@ -0,0 +1,65 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirect(t *testing.T) {
+	app := echonext.New()
+
+	app.GET("/s/:code", func(c echo.Context) (echonext.Redirect, error) {
+		return echonext.Redirect{URL: "https://example.com/" + c.Param("code")}, nil
+	})
+	app.GET("/moved", func(c echo.Context) (echonext.Redirect, error) {
+		return echonext.Redirect{Status: http.StatusMovedPermanently, URL: "/new"}, nil
+	}, echonext.Route{SuccessStatus: http.StatusMovedPermanently})
+	app.GET("/broken", func(c echo.Context) (echonext.Redirect, error) {
+		return echonext.Redirect{Status: http.StatusOK, URL: "/new"}, nil
+	})
+
+	t.Run("default status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/s/abc", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://example.com/abc", rec.Header().Get(echo.HeaderLocation))
+		assert.NotContains(t, rec.Body.String(), "success")
+	})
+
+	t.Run("explicit status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/moved", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+		assert.Equal(t, "/new", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/broken", nil)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+		assert.Contains(t, rec.Body.String(), "Invalid redirect status 200: must be 3xx")
+	})
+
+	t.Run("documented as redirect", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+
+		short := spec.Paths["/s/{code}"].Get.Responses
+		assert.Contains(t, short, "302")
+		assert.NotContains(t, short, "200")
+		assert.Contains(t, short["302"].Value.Headers, echo.HeaderLocation)
+
+		assert.Contains(t, spec.Paths["/moved"].Get.Responses, "301")
+	})
+}
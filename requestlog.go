@@ -0,0 +1,55 @@
+package echonext
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestLog describes a request handled by a typed route, for audit logs.
+// Bodies are redacted like Redact does.
+type RequestLog struct {
+	Method   string        `json:"method"`
+	Path     string        `json:"path"`  // Request path
+	Route    string        `json:"route"` // Registered path, such as /users/:id
+	Status   int           `json:"status"`
+	Duration time.Duration `json:"duration"`
+	Request  interface{}   `json:"request,omitempty"`  // Bound request; nil for routes without one
+	Response interface{}   `json:"response,omitempty"` // Handler result; nil on errors and for streams and files
+	Error    string        `json:"error,omitempty"`    // Error returned by the handler
+}
+
+// SetRequestLogger calls log once each typed request that reached its handler
+// has been answered, with the bound request and the handler's result
+// redacted. Requests rejected before the handler, e.g. by validation, are not
+// logged. Pass nil to stop logging.
+//
+//	app.SetRequestLogger(func(c echo.Context, entry echonext.RequestLog) {
+//		auditLog.Info("request", "entry", entry)
+//	})
+func (app *App) SetRequestLogger(log func(c echo.Context, entry RequestLog)) {
+	app.requestLogger = log
+}
+
+// logRequest reports a handled request to the request logger. req points to
+// the bound request and result is the handler's data result; either may be
+// invalid. Streamed results aren't logged.
+func (app *App) logRequest(c echo.Context, start time.Time, req, result reflect.Value, streamed bool, err error) {
+	entry := RequestLog{
+		Method:   c.Request().Method,
+		Path:     c.Request().URL.Path,
+		Route:    c.Path(),
+		Status:   c.Response().Status,
+		Duration: time.Since(start),
+	}
+	if req.IsValid() {
+		entry.Request = Redact(req.Interface())
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	} else if result.IsValid() && !streamed {
+		entry.Response = Redact(result.Interface())
+	}
+	app.requestLogger(c, entry)
+}
@@ -0,0 +1,69 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SignInRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password,redact" validate:"required"`
+	Device   struct {
+		Name  string `json:"name"`
+		Token string `json:"token" sensitive:"true"`
+	} `json:"device"`
+}
+
+type SignInResponse struct {
+	Username     string `json:"username"`
+	SessionToken string `json:"session_token" sensitive:"true"`
+}
+
+func TestRequestLogger(t *testing.T) {
+	var entries []echonext.RequestLog
+	app := echonext.New()
+	app.SetRequestLogger(func(c echo.Context, entry echonext.RequestLog) {
+		entries = append(entries, entry)
+	})
+	app.POST("/sessions", func(c echo.Context, req SignInRequest) (SignInResponse, error) {
+		if req.Password != "hunter22" {
+			return SignInResponse{}, echo.NewHTTPError(http.StatusUnauthorized, "invalid credentials")
+		}
+		return SignInResponse{Username: req.Username, SessionToken: "s3cr3t"}, nil
+	})
+
+	post := func(body string) {
+		req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	post(`{"username":"ada","password":"hunter22","device":{"name":"laptop","token":"t0k"}}`)
+	post(`{"username":"ada","password":"wrong"}`)
+	post(`{"username":"ada"}`)
+
+	require.Len(t, entries, 2, "requests rejected by validation don't reach the handler")
+
+	ok := entries[0]
+	assert.Equal(t, http.MethodPost, ok.Method)
+	assert.Equal(t, "/sessions", ok.Route)
+	assert.Equal(t, http.StatusOK, ok.Status)
+	assert.Equal(t, map[string]interface{}{
+		"username": "ada",
+		"password": echonext.RedactedValue,
+		"device":   map[string]interface{}{"name": "laptop", "token": echonext.RedactedValue},
+	}, ok.Request)
+	assert.Equal(t, map[string]interface{}{"username": "ada", "session_token": echonext.RedactedValue}, ok.Response)
+
+	failed := entries[1]
+	assert.Equal(t, http.StatusUnauthorized, failed.Status)
+	assert.Contains(t, failed.Error, "invalid credentials")
+	assert.Nil(t, failed.Response)
+	assert.Equal(t, echonext.RedactedValue, failed.Request.(map[string]interface{})["password"])
+}
@@ -0,0 +1,92 @@
+package echonext
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ResponseSpec documents one response status of a route
+type ResponseSpec struct {
+	Description string                // Defaults to the status text
+	Body        interface{}           // Value of the type sent, e.g. Job{}; nil documents the route's own success or error body
+	NoBody      bool                  // The response has no body
+	Headers     map[string]HeaderInfo // Headers sent with the response
+}
+
+// checkResponses panics on statuses that aren't valid HTTP statuses
+func checkResponses(responses map[int]ResponseSpec) {
+	for status := range responses {
+		if status < 100 || status > 599 {
+			panic(fmt.Sprintf("echonext: invalid response status %d", status))
+		}
+	}
+}
+
+// addDeclaredResponses documents the statuses listed in Route.Responses,
+// replacing any documented by default. Success statuses without a Body share
+// the content of the route's own success response; error statuses share the
+// ErrorResponse component.
+func (app *App) addDeclaredResponses(operation *openapi3.Operation, route *Route, errorSchema *openapi3.SchemaRef) {
+	if route == nil || len(route.Responses) == 0 {
+		return
+	}
+
+	// The lowest documented 2xx is the route's own success response
+	var primary *openapi3.Response
+	for code := http.StatusOK; code < 300 && primary == nil; code++ {
+		if ref, ok := operation.Responses[strconv.Itoa(code)]; ok {
+			primary = ref.Value
+		}
+	}
+
+	statuses := make([]int, 0, len(route.Responses))
+	for status := range route.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+
+	for _, status := range statuses {
+		spec := route.Responses[status]
+		description := spec.Description
+		if description == "" {
+			description = http.StatusText(status)
+		}
+		response := &openapi3.Response{Description: strPtr(description)}
+
+		switch {
+		case spec.NoBody:
+		case spec.Body != nil:
+			schema := app.schemaRef(reflect.TypeOf(spec.Body))
+			if status < http.StatusBadRequest {
+				schema = app.envelope.SuccessSchema(schema)
+			}
+			response.Content = openapi3.Content{
+				"application/json": &openapi3.MediaType{Schema: schema},
+			}
+		case status >= http.StatusBadRequest:
+			response.Content = openapi3.Content{
+				"application/json": &openapi3.MediaType{Schema: errorSchema},
+			}
+		case primary != nil:
+			response.Content = primary.Content
+			response.Headers = primary.Headers
+		}
+
+		if len(spec.Headers) > 0 {
+			headers := make(openapi3.Headers, len(response.Headers)+len(spec.Headers))
+			for name, header := range response.Headers {
+				headers[name] = header
+			}
+			for name, info := range spec.Headers {
+				headers[name] = headerRef(info)
+			}
+			response.Headers = headers
+		}
+		operation.Responses[strconv.Itoa(status)] = &openapi3.ResponseRef{Value: response}
+	}
+}
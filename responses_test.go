@@ -0,0 +1,64 @@
+package echonext_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type ImportJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func TestDeclaredResponses(t *testing.T) {
+	app := echonext.New()
+	app.PUT("/users/:id", func(c echo.Context, req CreateUserRequest) (TestUser, int, error) {
+		return TestUser{ID: c.Param("id")}, http.StatusCreated, nil
+	}, echonext.Route{
+		Responses: map[int]echonext.ResponseSpec{
+			http.StatusOK:       {Description: "User updated"},
+			http.StatusCreated:  {Description: "User created", Headers: map[string]echonext.HeaderInfo{"Location": {Description: "URL of the user"}}},
+			http.StatusAccepted: {Description: "Import queued", Body: ImportJob{}},
+			http.StatusConflict: {Description: "Email already taken"},
+		},
+	})
+	app.DELETE("/users/:id", func(c echo.Context) error { return nil }, echonext.Route{
+		Responses: map[int]echonext.ResponseSpec{http.StatusAccepted: {NoBody: true}},
+	})
+
+	spec := app.GenerateOpenAPISpec()
+	responses := spec.Paths["/users/{id}"].Put.Responses
+
+	ok := responses["200"].Value
+	assert.Equal(t, "User updated", *ok.Description)
+	userSchema := ok.Content["application/json"].Schema.Value.Properties["data"]
+	assert.Equal(t, "#/components/schemas/TestUser", userSchema.Ref)
+
+	created := responses["201"].Value
+	assert.Equal(t, "User created", *created.Description)
+	assert.Equal(t, userSchema.Ref, created.Content["application/json"].Schema.Value.Properties["data"].Ref, "statuses without a body share the route's")
+	assert.Contains(t, created.Headers, "Location")
+
+	accepted := responses["202"].Value
+	assert.Equal(t, "#/components/schemas/ImportJob", accepted.Content["application/json"].Schema.Value.Properties["data"].Ref)
+
+	conflict := responses["409"].Value
+	assert.Equal(t, "Email already taken", *conflict.Description)
+	assert.Equal(t, "#/components/schemas/ErrorResponse", conflict.Content["application/json"].Schema.Ref)
+	assert.Contains(t, responses, "400", "default responses are kept")
+
+	deleted := spec.Paths["/users/{id}"].Delete.Responses
+	assert.Equal(t, "Accepted", *deleted["202"].Value.Description)
+	assert.Nil(t, deleted["202"].Value.Content)
+	assert.Contains(t, deleted, "204")
+
+	assert.Panics(t, func() {
+		app.GET("/bad", func(c echo.Context) error { return nil }, echonext.Route{
+			Responses: map[int]echonext.ResponseSpec{42: {}},
+		})
+	})
+}
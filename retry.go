@@ -0,0 +1,54 @@
+package echonext
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Retry calls fn up to attempts times, doubling the wait after each failure
+// starting at backoff. It stops early when ctx is cancelled or when the next
+// wait would run past the context deadline, returning the last error from fn.
+// Pass c.Request().Context() so retries honour the request's lifetime.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err != nil {
+				return fmt.Errorf("%w (last error: %w)", ctxErr, err)
+			}
+			return ctxErr
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+		if i == attempts-1 {
+			break
+		}
+
+		// Doubling long backoffs overflows, so the wait stops growing at the
+		// longest Duration
+		wait := time.Duration(math.MaxInt64)
+		if i < 63 && backoff <= wait>>i {
+			wait = backoff << i
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+	return err
+}
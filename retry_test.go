@@ -0,0 +1,61 @@
+package echonext_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	errFlaky := errors.New("flaky")
+
+	t.Run("success after retry", func(t *testing.T) {
+		calls := 0
+		err := echonext.Retry(context.Background(), 3, time.Millisecond, func() error {
+			calls++
+			if calls < 3 {
+				return errFlaky
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		calls := 0
+		err := echonext.Retry(context.Background(), 2, time.Millisecond, func() error {
+			calls++
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, errFlaky)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := echonext.Retry(ctx, 5, time.Hour, func() error {
+			calls++
+			cancel()
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops before deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := echonext.Retry(ctx, 5, time.Second, func() error {
+			return errFlaky
+		})
+		assert.ErrorIs(t, err, errFlaky)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,113 @@
+package echonext
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Routes returns the typed routes in registration order, including disabled
+// ones. The result is a copy; changing it doesn't affect the app, though
+// values held as interface{}, such as examples, are shared.
+func (app *App) Routes() []RouteInfo {
+	routes := make([]RouteInfo, len(app.routes))
+	for i, route := range app.routes {
+		route.Tags = slices.Clone(route.Tags)
+		if route.RouteConfig != nil {
+			route.RouteConfig = route.RouteConfig.clone()
+		}
+		routes[i] = route
+	}
+	return routes
+}
+
+// clone returns a copy of the route sharing no slices, maps or pointers with
+// it, apart from those inside interface{} values
+func (r Route) clone() *Route {
+	r.Tags = slices.Clone(r.Tags)
+	r.Security = slices.Clone(r.Security)
+	for i, security := range r.Security {
+		r.Security[i].Scopes = slices.Clone(security.Scopes)
+		if security.Flows != nil {
+			flows := *security.Flows
+			for _, flow := range []**OAuthFlow{&flows.AuthorizationCode, &flows.ClientCredentials, &flows.Implicit, &flows.Password} {
+				if *flow != nil {
+					copied := **flow
+					copied.Scopes = maps.Clone(copied.Scopes)
+					*flow = &copied
+				}
+			}
+			r.Security[i].Flows = &flows
+		}
+	}
+	r.RequestHeaders = maps.Clone(r.RequestHeaders)
+	r.ResponseHeaders = maps.Clone(r.ResponseHeaders)
+	r.ContentTypes = slices.Clone(r.ContentTypes)
+	r.ContentSchemas = maps.Clone(r.ContentSchemas)
+	r.Examples = maps.Clone(r.Examples)
+	r.RateLimit = clonePtr(r.RateLimit)
+	r.Features = slices.Clone(r.Features)
+	r.Timeout = clonePtr(r.Timeout)
+	r.Enabled = clonePtr(r.Enabled)
+	r.Middleware = slices.Clone(r.Middleware)
+	r.Statuses = slices.Clone(r.Statuses)
+	r.Sunset = clonePtr(r.Sunset)
+	r.Responses = maps.Clone(r.Responses)
+	for status, response := range r.Responses {
+		response.Headers = maps.Clone(response.Headers)
+		r.Responses[status] = response
+	}
+	r.Extensions = maps.Clone(r.Extensions)
+	return &r
+}
+
+// clonePtr returns a pointer to a copy of *p, or nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	copied := *p
+	return &copied
+}
+
+// PrintRoutes writes a table of the typed routes: method, path, handler,
+// request and response types and tags, in registration order. Disabled
+// routes are marked.
+func (app *App) PrintRoutes(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tHANDLER\tREQUEST\tRESPONSE\tTAGS")
+	for _, route := range app.routes {
+		path := route.Path
+		if !route.isEnabled() {
+			path += " (disabled)"
+		}
+		handler := handlerName(route.Handler)
+		if handler == "" {
+			handler = "-"
+		}
+		tags := strings.Join(app.routeTags(route), ",")
+		if tags == "" {
+			tags = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", route.Method, path, handler,
+			shortTypeName(route.RequestType), shortTypeName(route.ResponseType), tags)
+	}
+	return tw.Flush()
+}
+
+// packagePath matches the import path qualifying a type name
+var packagePath = regexp.MustCompile(`[\w.\-~]+/`)
+
+// shortTypeName names a type without import paths, e.g.
+// "echonext.Page[main.Todo]", or "-" for none
+func shortTypeName(t reflect.Type) string {
+	if t == nil {
+		return "-"
+	}
+	return packagePath.ReplaceAllString(t.String(), "")
+}
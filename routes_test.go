@@ -0,0 +1,62 @@
+package echonext_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listRoutesUsers(c echo.Context) ([]TestUser, error) { return nil, nil }
+
+func TestRoutes(t *testing.T) {
+	app := echonext.New()
+	app.SetAutoTags(true)
+	disabled := false
+	app.GET("/users", listRoutesUsers)
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (*TestUser, error) { return &TestUser{}, nil }, echonext.Route{
+		Tags:      []string{"accounts"},
+		Security:  []echonext.Security{{Type: "oauth2", Scopes: []string{"users:write"}}},
+		Responses: map[int]echonext.ResponseSpec{http.StatusConflict: {Description: "Email taken"}},
+		Extensions: map[string]interface{}{
+			"x-internal": true,
+		},
+	})
+	app.GET("/tasks", func(c echo.Context) (echonext.Page[Task], error) { return echonext.Page[Task]{}, nil })
+	app.DELETE("/users/:id", func(c echo.Context) error { return nil }, echonext.Route{Enabled: &disabled})
+
+	routes := app.Routes()
+	require.Len(t, routes, 4)
+	assert.Equal(t, "GET", routes[0].Method)
+	assert.Equal(t, "/users", routes[0].Path)
+	assert.Nil(t, routes[0].RequestType)
+	assert.Equal(t, "CreateUserRequest", routes[1].RequestType.Name())
+	assert.Equal(t, []string{"accounts"}, routes[1].Tags)
+
+	// The copy doesn't share state with the app
+	routes[1].Tags[0] = "changed"
+	routes[1].RouteConfig.Tags[0] = "changed"
+	routes[1].RouteConfig.Security[0].Scopes[0] = "changed"
+	routes[1].RouteConfig.Responses[http.StatusConflict] = echonext.ResponseSpec{}
+	routes[1].RouteConfig.Extensions["x-internal"] = false
+	config := app.Routes()[1].RouteConfig
+	assert.Equal(t, []string{"accounts"}, app.Routes()[1].Tags)
+	assert.Equal(t, []string{"accounts"}, config.Tags)
+	assert.Equal(t, []string{"users:write"}, config.Security[0].Scopes)
+	assert.Equal(t, "Email taken", config.Responses[http.StatusConflict].Description)
+	assert.Equal(t, true, config.Extensions["x-internal"])
+
+	var b strings.Builder
+	require.NoError(t, app.PrintRoutes(&b))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, []string{"METHOD", "PATH", "HANDLER", "REQUEST", "RESPONSE", "TAGS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"GET", "/users", "listRoutesUsers", "-", "[]echonext_test.TestUser", "users"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"POST", "/users", "TestRoutes.func1", "echonext_test.CreateUserRequest", "*echonext_test.TestUser", "accounts"}, strings.Fields(lines[2]))
+	assert.Equal(t, "echonext.Page[echonext_test.Task]", strings.Fields(lines[3])[4])
+	assert.Equal(t, []string{"DELETE", "/users/:id", "(disabled)"}, strings.Fields(lines[4])[:3])
+}
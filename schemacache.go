@@ -0,0 +1,48 @@
+package echonext
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// generateSchema returns the schema for t, reflecting each type only once.
+// Callers get a copy they may decorate without affecting the cache.
+func (app *App) generateSchema(t reflect.Type) *openapi3.Schema {
+	schema, ok := app.schemaCache[t]
+	if !ok {
+		schema = app.buildSchema(t)
+		if app.schemaCache == nil {
+			app.schemaCache = make(map[reflect.Type]*openapi3.Schema)
+		}
+		app.schemaCache[t] = schema
+	}
+	return cloneSchema(schema)
+}
+
+// invalidateSchemas drops cached schemas after registrations that change how
+// types are documented
+func (app *App) invalidateSchemas() {
+	app.schemaCache = nil
+	app.componentNames = nil
+	app.spec.Components.Schemas = openapi3.Schemas{}
+}
+
+// cloneSchema copies the fields of a schema that callers modify in place.
+// Nested schemas are shared.
+func cloneSchema(schema *openapi3.Schema) *openapi3.Schema {
+	clone := *schema
+	if schema.Enum != nil {
+		clone.Enum = append([]interface{}(nil), schema.Enum...)
+	}
+	if schema.Required != nil {
+		clone.Required = append([]string(nil), schema.Required...)
+	}
+	if schema.Extensions != nil {
+		clone.Extensions = make(map[string]interface{}, len(schema.Extensions))
+		for k, v := range schema.Extensions {
+			clone.Extensions[k] = v
+		}
+	}
+	return &clone
+}
@@ -0,0 +1,122 @@
+package echonext_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Author struct {
+	Name string `json:"name" example:"Ada" validate:"required,handle"`
+}
+
+type Reviewer struct {
+	Name string `json:"name" example:"Grace" validate:"max=20"`
+}
+
+func TestSchemaCache(t *testing.T) {
+	app := echonext.New()
+	app.POST("/authors", func(c echo.Context, req Author) (Author, error) { return req, nil })
+	app.POST("/reviewers", func(c echo.Context, req Reviewer) (Reviewer, error) { return req, nil })
+
+	bodySchema := func(spec *openapi3.T, path string) *openapi3.Schema {
+		return spec.Paths[path].Post.RequestBody.Value.Content["application/json"].Schema.Value
+	}
+
+	t.Run("field decorations stay separate", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		author := bodySchema(spec, "/authors").Properties["name"].Value
+		reviewer := bodySchema(spec, "/reviewers").Properties["name"].Value
+
+		assert.Equal(t, "Ada", author.Example)
+		assert.Nil(t, author.MaxLength)
+		assert.Equal(t, "Grace", reviewer.Example)
+		assert.Equal(t, uint64(20), *reviewer.MaxLength)
+	})
+
+	t.Run("invalidated by later registrations", func(t *testing.T) {
+		assert.Empty(t, bodySchema(app.GenerateOpenAPISpec(), "/authors").Properties["name"].Value.Pattern)
+
+		app.RegisterTagSchema("handle", func(s *openapi3.Schema) { s.Pattern = "^[A-Za-z]+$" })
+		assert.Equal(t, "^[A-Za-z]+$", bodySchema(app.GenerateOpenAPISpec(), "/authors").Properties["name"].Value.Pattern)
+	})
+}
+
+// newSharedTypesApp registers many routes sharing a handful of types
+func newSharedTypesApp(routes int) *echonext.App {
+	app := echonext.New()
+	for i := 0; i < routes; i++ {
+		app.POST(fmt.Sprintf("/posts/%d", i), func(c echo.Context, req CreatePostRequest) (Post, error) {
+			return Post{}, nil
+		})
+		app.GET(fmt.Sprintf("/posts/%d", i), func(c echo.Context) ([]Post, error) {
+			return nil, nil
+		})
+	}
+	return app
+}
+
+type CreatePostRequest struct {
+	Title   string   `json:"title" validate:"required,min=3,max=200"`
+	Content string   `json:"content" validate:"required,min=10"`
+	Tags    []string `json:"tags" validate:"max=5"`
+	Author  Author   `json:"author"`
+}
+
+type Post struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	Tags      []string   `json:"tags"`
+	Author    Author     `json:"author"`
+	Reviewers []Reviewer `json:"reviewers"`
+}
+
+func BenchmarkGenerateOpenAPISpec(b *testing.B) {
+	b.Run("first", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			app := newSharedTypesApp(50)
+			b.StartTimer()
+			app.GenerateOpenAPISpec()
+		}
+	})
+	b.Run("repeated", func(b *testing.B) {
+		app := newSharedTypesApp(50)
+		app.GenerateOpenAPISpec()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			app.GenerateOpenAPISpec()
+		}
+	})
+}
+
+func TestConcurrentSpecServing(t *testing.T) {
+	app := echonext.New()
+	app.GET("/authors", func(c echo.Context) ([]Author, error) { return nil, nil })
+	app.POST("/reviews", func(c echo.Context, req Reviewer) (Author, error) { return Author{}, nil })
+	app.ServeOpenAPISpec("/openapi.json")
+
+	want, err := app.MarshalOpenAPISpec()
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+			assert.JSONEq(t, string(want), rec.Body.String())
+			assert.NoError(t, app.ValidateSpec())
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,118 @@
+package echonext
+
+import (
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetScopeResolver returns the scopes held by the caller of a request.
+// Response fields tagged `scope:"admin"` are stripped unless the caller holds
+// that scope; without a resolver scoped fields are never returned.
+func (app *App) SetScopeResolver(resolver func(c echo.Context) []string) {
+	app.scopeResolver = resolver
+}
+
+// hasScopedFields reports whether t contains a field tagged with scope
+func hasScopedFields(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	return containsScopedField(t, map[reflect.Type]bool{})
+}
+
+func containsScopedField(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return containsScopedField(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Tag.Get("scope") != "" || containsScopedField(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterScopedFields converts data into a JSON value tree without the fields
+// the caller may not see
+func (app *App) filterScopedFields(c echo.Context, t reflect.Type, data interface{}) (interface{}, error) {
+	held := map[string]bool{}
+	if app.scopeResolver != nil {
+		for _, scope := range app.scopeResolver(c) {
+			held[scope] = true
+		}
+	}
+
+	tree, err := jsonTree(data)
+	if err != nil {
+		return nil, err
+	}
+	return walkScopes(t, tree, held), nil
+}
+
+// walkScopes walks a decoded JSON tree alongside its Go type, deleting
+// properties whose required scope is not held
+func walkScopes(t reflect.Type, node interface{}, held map[string]bool) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if items, ok := node.([]interface{}); ok {
+			for i, item := range items {
+				items[i] = walkScopes(t.Elem(), item, held)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := node.(map[string]interface{}); ok {
+			for k, v := range obj {
+				obj[k] = walkScopes(t.Elem(), v, held)
+			}
+		}
+	case reflect.Struct:
+		if obj, ok := node.(map[string]interface{}); ok {
+			walkScopeFields(t, obj, held)
+		}
+	}
+	return node
+}
+
+func walkScopeFields(t reflect.Type, obj map[string]interface{}, held map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened into the parent
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && fieldType.Kind() == reflect.Struct {
+			walkScopeFields(fieldType, obj, held)
+			continue
+		}
+
+		if scope := field.Tag.Get("scope"); scope != "" && !held[scope] {
+			delete(obj, name)
+			continue
+		}
+		if v, exists := obj[name]; exists {
+			obj[name] = walkScopes(field.Type, v, held)
+		}
+	}
+}
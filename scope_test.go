@@ -0,0 +1,66 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Account struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Internal string `json:"internal_notes" scope:"admin"`
+}
+
+func TestScopedFields(t *testing.T) {
+	app := echonext.New()
+	app.SetScopeResolver(func(c echo.Context) []string {
+		return strings.Split(c.Request().Header.Get("X-Scopes"), ",")
+	})
+
+	account := Account{ID: "1", Email: "john@example.com", Internal: "VIP customer"}
+	app.GET("/accounts/:id", func(c echo.Context) (Account, error) {
+		return account, nil
+	})
+	app.GET("/accounts", func(c echo.Context) ([]Account, error) {
+		return []Account{account}, nil
+	})
+
+	get := func(path, scopes string) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Scopes", scopes)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		var response echonext.Response[Account]
+		assert.NoError(t, json.Unmarshal([]byte(get("/accounts/1", "read,admin")), &response))
+		assert.Equal(t, account, response.Data)
+	})
+
+	t.Run("regular user", func(t *testing.T) {
+		body := get("/accounts/1", "read")
+		assert.NotContains(t, body, "internal_notes")
+		assert.Contains(t, body, `"email":"john@example.com"`)
+	})
+
+	t.Run("nested in list", func(t *testing.T) {
+		assert.NotContains(t, get("/accounts", "read"), "internal_notes")
+		assert.Contains(t, get("/accounts", "admin"), "internal_notes")
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		data := spec.Paths["/accounts/{id}"].Get.Responses["200"].Value.Content["application/json"].Schema.Value.Properties["data"].Value
+		assert.Equal(t, "admin", data.Properties["internal_notes"].Value.Extensions["x-required-scope"])
+	})
+}
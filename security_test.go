@@ -0,0 +1,41 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalSecurity(t *testing.T) {
+	app := echonext.New()
+	app.AddSecurityScheme("bearerAuth", echonext.Security{Type: "bearer"})
+	app.AddSecurityScheme("X-API-Key", echonext.Security{Type: "apiKey", Name: "X-API-Key", In: "header"})
+	app.SetGlobalSecurity([]echonext.Security{{Type: "bearer"}})
+
+	app.GET("/todos", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+	app.GET("/health", func(c echo.Context) (TestUser, error) { return TestUser{}, nil }, echonext.Route{Public: true})
+	app.GET("/reports", func(c echo.Context) (TestUser, error) { return TestUser{}, nil }, echonext.Route{
+		Security: []echonext.Security{{Type: "apiKey", Name: "X-API-Key"}},
+	})
+
+	data, err := app.MarshalOpenAPISpec()
+	assert.NoError(t, err)
+	var doc struct {
+		Security []map[string][]string `json:"security"`
+		Paths    map[string]map[string]struct {
+			Security *[]map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &doc))
+
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, doc.Security)
+	assert.Nil(t, doc.Paths["/todos"]["get"].Security, "inherits the global requirement")
+	if assert.NotNil(t, doc.Paths["/health"]["get"].Security) {
+		assert.Empty(t, *doc.Paths["/health"]["get"].Security, "public routes override it with an empty list")
+	}
+	assert.Equal(t, &[]map[string][]string{{"X-API-Key": {}}}, doc.Paths["/reports"]["get"].Security)
+	assert.NoError(t, app.ValidateSpec())
+}
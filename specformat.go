@@ -0,0 +1,99 @@
+package echonext
+
+import (
+	"crypto/sha256"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v3"
+)
+
+// MIMEApplicationYAML is the content type of YAML specs
+const MIMEApplicationYAML = "application/yaml"
+
+// yamlMediaTypes are the Accept values answered with YAML
+var yamlMediaTypes = map[string]bool{
+	MIMEApplicationYAML:  true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+	"text/x-yaml":        true,
+}
+
+// wantsYAML reports whether the spec should be sent as YAML, either because
+// of a ?format=yaml override, a .yaml or .yml route path, or because the
+// Accept header asks for it
+func wantsYAML(c echo.Context) bool {
+	switch strings.ToLower(c.QueryParam("format")) {
+	case "yaml", "yml":
+		return true
+	case "json":
+		return false
+	}
+	if strings.HasSuffix(c.Path(), ".yaml") || strings.HasSuffix(c.Path(), ".yml") {
+		return true
+	}
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && yamlMediaTypes[mediaType] {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeOpenAPISpecYAML serves the OpenAPI specification as YAML only, for
+// tools that cannot negotiate
+func (app *App) ServeOpenAPISpecYAML(path string) {
+	app.Echo.GET(path, func(c echo.Context) error {
+		return app.writeSpec(c, true)
+	})
+}
+
+// writeSpec sends the generated spec as YAML or JSON, logging any problems
+// ValidateSpec would report. The spec is generated for every request but
+// only validated when it changes, so an invalid spec is reported once.
+func (app *App) writeSpec(c echo.Context, asYAML bool) error {
+	data, err := app.marshalSpec(func(spec *openapi3.T, data []byte) {
+		digest := sha256.Sum256(data)
+		if digest == app.validatedSpec {
+			return
+		}
+		app.validatedSpec = digest
+		if err := validateSpec(spec); err != nil {
+			app.Logger.Errorf("echonext: generated OpenAPI spec is invalid: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !asYAML {
+		return c.JSONBlob(http.StatusOK, data)
+	}
+	if data, err = specYAML(data); err != nil {
+		return err
+	}
+	return c.Blob(http.StatusOK, MIMEApplicationYAML, data)
+}
+
+// specYAML converts a JSON spec to block-style YAML, so extensions and refs
+// are written exactly as in the JSON spec
+func specYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+// clearStyle drops the flow and quoting styles carried over from JSON so the
+// encoder picks plain block style where it can
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
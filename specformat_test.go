@@ -0,0 +1,75 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestServeOpenAPISpecFormats(t *testing.T) {
+	app := echonext.New()
+	app.SetInfo("Todo API", "1.0.0", "")
+	app.GET("/todos", func(c echo.Context) ([]TestUser, error) {
+		return nil, nil
+	}, echonext.Route{Summary: "List todos"})
+	app.ServeOpenAPISpec("/openapi")
+	app.ServeOpenAPISpec("/openapi.yaml")
+	app.ServeOpenAPISpecYAML("/spec")
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		return rec
+	}
+
+	assertYAML := func(t *testing.T, rec *httptest.ResponseRecorder) {
+		assert.Equal(t, echonext.MIMEApplicationYAML, rec.Header().Get(echo.HeaderContentType))
+		var doc map[string]interface{}
+		assert.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "Todo API", doc["info"].(map[string]interface{})["title"])
+		assert.Contains(t, rec.Body.String(), "summary: List todos")
+	}
+
+	assertJSON := func(t *testing.T, rec *httptest.ResponseRecorder) {
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+		var doc map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.0", doc["openapi"])
+	}
+
+	t.Run("default json", func(t *testing.T) {
+		assertJSON(t, get("/openapi", ""))
+		assertJSON(t, get("/openapi", "application/json, */*"))
+	})
+
+	t.Run("accept header", func(t *testing.T) {
+		assertYAML(t, get("/openapi", "application/yaml"))
+		assertYAML(t, get("/openapi", "text/html, application/x-yaml;q=0.9"))
+	})
+
+	t.Run("path suffix", func(t *testing.T) {
+		assertYAML(t, get("/openapi.yaml", ""))
+		assertJSON(t, get("/openapi.yaml?format=json", ""))
+	})
+
+	t.Run("yaml only", func(t *testing.T) {
+		assertYAML(t, get("/spec", ""))
+		assertYAML(t, get("/spec", echo.MIMEApplicationJSON))
+	})
+
+	t.Run("query override", func(t *testing.T) {
+		assertYAML(t, get("/openapi?format=yaml", ""))
+		assertJSON(t, get("/openapi?format=json", "application/yaml"))
+	})
+}
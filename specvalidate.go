@@ -0,0 +1,184 @@
+package echonext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ValidateSpec generates the spec and checks it with kin-openapi's validator.
+// Each component schema and operation is validated separately so every
+// problem is reported, along with empty enums and duplicate operation IDs
+// that the validator accepts. Problems are joined into one error.
+func (app *App) ValidateSpec() error {
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	return validateSpec(app.generateSpec())
+}
+
+func validateSpec(spec *openapi3.T) error {
+	ctx := context.Background()
+	var errs []error
+
+	if spec.Info == nil {
+		errs = append(errs, errors.New("info: must be an object"))
+	} else if err := spec.Info.Validate(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("info: %w", err))
+	}
+
+	if spec.Components != nil {
+		names := make([]string, 0, len(spec.Components.Schemas))
+		for name := range spec.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			errs = append(errs, validateSchema(ctx, "schema "+name, spec.Components.Schemas[name])...)
+		}
+
+		others := *spec.Components
+		others.Schemas = nil
+		if err := others.Validate(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("components: %w", err))
+		}
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	operationIDs := map[string]string{}
+	for _, path := range paths {
+		operations := spec.Paths[path].Operations()
+		methods := make([]string, 0, len(operations))
+		for method := range operations {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			operation := operations[method]
+			where := method + " " + path
+			if err := operation.Validate(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", where, err))
+			}
+			errs = append(errs, operationEnums(where, operation)...)
+			if id := operation.OperationID; id != "" {
+				if first, taken := operationIDs[id]; taken {
+					errs = append(errs, fmt.Errorf("%s: operationId %q is already used by %s", where, id, first))
+				} else {
+					operationIDs[id] = where
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateSchema validates a component schema and checks it for empty enums
+func validateSchema(ctx context.Context, where string, ref *openapi3.SchemaRef) []error {
+	var errs []error
+	if err := ref.Validate(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	return append(errs, emptyEnums(where, ref.Value)...)
+}
+
+// operationEnums checks the inline schemas of an operation's parameters,
+// request body and responses for empty enums
+func operationEnums(where string, operation *openapi3.Operation) []error {
+	var errs []error
+	for _, param := range operation.Parameters {
+		if param.Value != nil && param.Value.Schema != nil && param.Value.Schema.Ref == "" {
+			errs = append(errs, emptyEnums(where+" parameter "+param.Value.Name, param.Value.Schema.Value)...)
+		}
+	}
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		errs = append(errs, contentEnums(where+" request body", operation.RequestBody.Value.Content)...)
+	}
+	statuses := make([]string, 0, len(operation.Responses))
+	for status := range operation.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if response := operation.Responses[status]; response.Value != nil {
+			errs = append(errs, contentEnums(where+" response "+status, response.Value.Content)...)
+		}
+	}
+	return errs
+}
+
+func contentEnums(where string, content openapi3.Content) []error {
+	var errs []error
+	for _, mediaType := range sortedMediaTypes(content) {
+		if schema := content[mediaType].Schema; schema != nil && schema.Ref == "" {
+			errs = append(errs, emptyEnums(where, schema.Value)...)
+		}
+	}
+	return errs
+}
+
+func sortedMediaTypes(content openapi3.Content) []string {
+	types := make([]string, 0, len(content))
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// emptyEnums reports enums with no values besides "" and null, such as those
+// from a bare "oneof=" tag, which only an empty value satisfies. Referenced
+// components are skipped; they are checked on their own.
+func emptyEnums(where string, schema *openapi3.Schema) []error {
+	var errs []error
+	seen := map[*openapi3.Schema]bool{}
+	var walk func(path string, schema *openapi3.Schema)
+	walk = func(path string, schema *openapi3.Schema) {
+		if schema == nil || seen[schema] {
+			return
+		}
+		seen[schema] = true
+		if schema.Enum != nil && blankEnum(schema.Enum) {
+			errs = append(errs, fmt.Errorf("%s%s: enum has no values", where, path))
+		}
+
+		child := func(path string, ref *openapi3.SchemaRef) {
+			if ref != nil && ref.Ref == "" {
+				walk(path, ref.Value)
+			}
+		}
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child(path+"."+name, schema.Properties[name])
+		}
+		child(path+"[]", schema.Items)
+		child(path+".*", schema.AdditionalProperties.Schema)
+		for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+			for _, sub := range group {
+				child(path, sub)
+			}
+		}
+	}
+	walk("", schema)
+	return errs
+}
+
+func blankEnum(values []interface{}) bool {
+	for _, value := range values {
+		if value != nil && value != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package echonext_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type BrokenRequest struct {
+	Status string `json:"status" validate:"oneof="`
+	Code   string `json:"code" validate:"sku"`
+}
+
+func TestValidateSpec(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		app := echonext.New()
+		app.POST("/users", func(c echo.Context, req CreateUserRequest) (TestUser, error) {
+			return TestUser{}, nil
+		})
+		assert.NoError(t, app.ValidateSpec())
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		app := echonext.New()
+		app.RegisterTagSchema("sku", func(s *openapi3.Schema) { s.Pattern = "[A-Z" })
+		app.POST("/items", func(c echo.Context, req BrokenRequest) (TestUser, error) {
+			return TestUser{}, nil
+		})
+		app.GET("/items", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+		app.AddSpecPostProcessor(func(spec *openapi3.T) {
+			spec.Paths["/items"].Get.OperationID = "items"
+			spec.Paths["/items"].Post.OperationID = "items"
+		})
+
+		err := app.ValidateSpec()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "schema BrokenRequest: error parsing regexp")
+			assert.Contains(t, err.Error(), "schema BrokenRequest.status: enum has no values")
+			assert.Contains(t, err.Error(), `POST /items: operationId "items" is already used by GET /items`)
+		}
+
+		var logs bytes.Buffer
+		app.Logger.SetOutput(&logs)
+		app.ServeOpenAPISpec("/openapi.json")
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+		// The unchanged spec is only validated and reported once
+		assert.Equal(t, 1, strings.Count(logs.String(), "generated OpenAPI spec is invalid"))
+	})
+}
@@ -0,0 +1,119 @@
+package echonext
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMETextEventStream is the content type of server-sent events
+const MIMETextEventStream = "text/event-stream"
+
+// Event is a server-sent event with its optional fields. Handlers returning
+// <-chan Event control the event name, ID and retry delay; other channel
+// element types are sent as data-only events.
+type Event struct {
+	ID    string        // Sets the client's last event ID
+	Name  string        // The event type; clients listen for "message" when empty
+	Data  interface{}   // Sent JSON-encoded
+	Retry time.Duration // Reconnection delay for the client; zero leaves it unchanged
+}
+
+var eventType = reflect.TypeOf(Event{})
+
+// isEventStream reports whether handlers returning t stream server-sent events
+func isEventStream(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Chan && t.ChanDir()&reflect.RecvDir != 0
+}
+
+// streamEvents sends each value received from events as a server-sent event
+// until the channel is closed or the client disconnects. Producers should
+// stop sending once c.Request().Context() is done.
+func streamEvents(c echo.Context, events reflect.Value) error {
+	if events.IsNil() {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, MIMETextEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // Keep proxies such as nginx from buffering
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.Request().Context().Done())},
+		{Dir: reflect.SelectRecv, Chan: events},
+	}
+	for {
+		chosen, value, ok := reflect.Select(cases)
+		if chosen == 0 || !ok {
+			return nil
+		}
+		frame, err := eventFrame(value.Interface())
+		if err != nil {
+			return fmt.Errorf("echonext: event stream aborted: %w", err)
+		}
+		if _, err := c.Response().Write(frame); err != nil {
+			return nil
+		}
+		c.Response().Flush()
+	}
+}
+
+// eventFrame encodes a value as an event-stream frame
+func eventFrame(value interface{}) ([]byte, error) {
+	event, ok := value.(Event)
+	if !ok {
+		event = Event{Data: value}
+	}
+
+	var frame strings.Builder
+	if event.ID != "" {
+		frame.WriteString("id: " + singleLine(event.ID) + "\n")
+	}
+	if event.Name != "" {
+		frame.WriteString("event: " + singleLine(event.Name) + "\n")
+	}
+	if event.Retry > 0 {
+		frame.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	frame.WriteString("data: ")
+	frame.Write(data)
+	frame.WriteString("\n\n")
+	return []byte(frame.String()), nil
+}
+
+// singleLine drops line breaks, which would end an event-stream field
+func singleLine(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// eventStreamResponse documents an event stream. The schema is that of each
+// event's data, or of any value for channels of Event.
+func (app *App) eventStreamResponse(t reflect.Type) *openapi3.Response {
+	var schema *openapi3.SchemaRef
+	if elem := t.Elem(); elem == eventType {
+		schema = &openapi3.SchemaRef{Value: &openapi3.Schema{}}
+	} else {
+		schema = app.schemaRef(elem)
+	}
+	return &openapi3.Response{
+		Description: strPtr("Stream of server-sent events, each with JSON-encoded data"),
+		Content: openapi3.Content{
+			MIMETextEventStream: &openapi3.MediaType{Schema: schema},
+		},
+	}
+}
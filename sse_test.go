@@ -0,0 +1,89 @@
+package echonext_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type Progress struct {
+	Percent int `json:"percent"`
+}
+
+func TestEventStreams(t *testing.T) {
+	app := echonext.New()
+	app.SetHandlerTimeout(time.Millisecond) // Streams outlive the default timeout
+	app.GET("/jobs/:id/progress", func(c echo.Context) (<-chan Progress, error) {
+		events := make(chan Progress)
+		go func() {
+			defer close(events)
+			for _, percent := range []int{50, 100} {
+				events <- Progress{Percent: percent}
+			}
+		}()
+		return events, nil
+	})
+	app.GET("/logs", func(c echo.Context) (<-chan echonext.Event, error) {
+		events := make(chan echonext.Event)
+		go func() {
+			defer close(events)
+			ctx := c.Request().Context()
+			for {
+				select {
+				case events <- echonext.Event{ID: "1", Name: "log", Data: "started\nline", Retry: 3 * time.Second}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return events, nil
+	})
+	app.GET("/missing", func(c echo.Context) (<-chan Progress, error) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "job not found")
+	})
+
+	t.Run("values are data frames", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/jobs/1/progress", nil)
+		req.Header.Set(echo.HeaderAccept, echonext.MIMETextEventStream)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, echonext.MIMETextEventStream, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "data: {\"percent\":50}\n\ndata: {\"percent\":100}\n\n", rec.Body.String())
+	})
+
+	t.Run("events set their fields and stop on disconnect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/logs", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		time.AfterFunc(20*time.Millisecond, cancel)
+		app.ServeHTTP(rec, req)
+
+		assert.Contains(t, rec.Body.String(), "id: 1\nevent: log\nretry: 3000\ndata: \"started\\nline\"\n\n")
+	})
+
+	t.Run("errors before streaming", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "job not found")
+	})
+
+	t.Run("spec", func(t *testing.T) {
+		spec := app.GenerateOpenAPISpec()
+		content := spec.Paths["/jobs/{id}/progress"].Get.Responses["200"].Value.Content
+		if assert.Contains(t, content, echonext.MIMETextEventStream) {
+			assert.Equal(t, "#/components/schemas/Progress", content[echonext.MIMETextEventStream].Schema.Ref)
+		}
+		assert.NotContains(t, content, echo.MIMEApplicationJSON)
+		assert.NoError(t, app.ValidateSpec())
+	})
+}
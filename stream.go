@@ -0,0 +1,102 @@
+package echonext
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamChunkSize is how much encoded output is buffered before the status
+// line is committed and the chunk is sent
+const streamChunkSize = 32 << 10
+
+// SetStreamingJSON encodes slice responses element by element straight to the
+// connection instead of marshaling the whole envelope in memory first. Large
+// list responses are sent with chunked transfer encoding. Streaming applies to
+// the standard envelope only.
+func (app *App) SetStreamingJSON(enabled bool) {
+	app.streamingJSON = enabled
+}
+
+// streamJSON writes the success envelope for data. Encoding errors found
+// before the first chunk is flushed still produce a 500 error envelope; after
+// that the status has been sent and the response is aborted.
+func streamJSON(c echo.Context, status int, data interface{}) error {
+	w := &deferredStatusWriter{c: c, status: status}
+	buf := bufio.NewWriterSize(w, streamChunkSize)
+
+	if err := encodeEnvelope(buf, data, requestWarnings(c)); err != nil {
+		if w.started {
+			return fmt.Errorf("echonext: streaming response aborted: %w", err)
+		}
+		return errorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+	return buf.Flush()
+}
+
+// encodeEnvelope writes {"data":...,"success":true}, encoding slice elements
+// one at a time so only a single element is held in memory
+func encodeEnvelope(w *bufio.Writer, data interface{}, warnings []string) error {
+	v := reflect.ValueOf(data)
+	isList := (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) &&
+		v.Type().Elem().Kind() != reflect.Uint8 && v.Len() > 0
+	if !isList {
+		b, err := json.Marshal(Response[any]{Data: data, Success: true, Warnings: warnings})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(b, '\n'))
+		return err
+	}
+
+	// Reuse one element buffer; Encode appends a newline that is dropped
+	var elem bytes.Buffer
+	enc := json.NewEncoder(&elem)
+
+	w.WriteString(`{"data":[`)
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		elem.Reset()
+		if err := enc.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+		if _, err := w.Write(elem.Bytes()[:elem.Len()-1]); err != nil {
+			return err
+		}
+	}
+	w.WriteString(`],"success":true`)
+	if len(warnings) > 0 {
+		b, err := json.Marshal(warnings)
+		if err != nil {
+			return err
+		}
+		w.WriteString(`,"warnings":`)
+		w.Write(b)
+	}
+	_, err := w.WriteString("}\n")
+	return err
+}
+
+// deferredStatusWriter commits the status and content type on the first write
+type deferredStatusWriter struct {
+	c       echo.Context
+	status  int
+	started bool
+}
+
+func (w *deferredStatusWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		res := w.c.Response()
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		res.WriteHeader(w.status)
+	}
+	return w.c.Response().Write(p)
+}
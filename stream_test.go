@@ -0,0 +1,95 @@
+package echonext_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func largeUserList(n int) []TestUser {
+	users := make([]TestUser, n)
+	for i := range users {
+		users[i] = TestUser{ID: fmt.Sprint(i), Name: "User <" + fmt.Sprint(i) + ">", Email: "user@example.com"}
+	}
+	return users
+}
+
+func newListApp(streaming bool, users []TestUser) *echonext.App {
+	app := echonext.New()
+	app.SetStreamingJSON(streaming)
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) {
+		return users, nil
+	}, echonext.Route{SuccessStatus: http.StatusPartialContent})
+	app.GET("/users/first", func(c echo.Context) (TestUser, error) {
+		return users[0], nil
+	})
+	app.GET("/broken", func(c echo.Context) ([]interface{}, error) {
+		return []interface{}{func() {}}, nil
+	})
+	return app
+}
+
+func TestStreamingJSON(t *testing.T) {
+	users := largeUserList(5000)
+	buffered := newListApp(false, users)
+	streaming := newListApp(true, users)
+
+	for _, path := range []string{"/users", "/users/first"} {
+		t.Run("same body "+path, func(t *testing.T) {
+			want := httptest.NewRecorder()
+			buffered.ServeHTTP(want, httptest.NewRequest(http.MethodGet, path, nil))
+
+			got := httptest.NewRecorder()
+			streaming.ServeHTTP(got, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, want.Code, got.Code)
+			assert.Equal(t, want.Header().Get(echo.HeaderContentType), got.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, want.Body.String(), got.Body.String())
+		})
+	}
+
+	t.Run("error before first chunk", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		streaming.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/broken", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"success":false`)
+	})
+}
+
+// discardResponseWriter drops the body, recording the largest single write
+// as a measure of how much of the response was buffered
+type discardResponseWriter struct {
+	header   http.Header
+	maxWrite int
+}
+
+func (w *discardResponseWriter) Header() http.Header { return w.header }
+func (w *discardResponseWriter) WriteHeader(int)     {}
+func (w *discardResponseWriter) Write(p []byte) (int, error) {
+	if len(p) > w.maxWrite {
+		w.maxWrite = len(p)
+	}
+	return len(p), nil
+}
+
+func BenchmarkLargeSliceResponse(b *testing.B) {
+	users := largeUserList(50000)
+	for _, streaming := range []bool{false, true} {
+		app := newListApp(streaming, users)
+		b.Run(fmt.Sprintf("streaming=%v", streaming), func(b *testing.B) {
+			b.ReportAllocs()
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			w := &discardResponseWriter{}
+			for i := 0; i < b.N; i++ {
+				w.header = http.Header{}
+				app.ServeHTTP(w, req)
+			}
+			b.ReportMetric(float64(w.maxWrite), "buffered-B")
+		})
+	}
+}
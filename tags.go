@@ -0,0 +1,66 @@
+package echonext
+
+import (
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SetAutoTags tags routes without explicit Tags by the first non-parameter
+// path segment, so /todos/:id is grouped under "todos"
+func (app *App) SetAutoTags(enabled bool) {
+	app.autoTags = enabled
+}
+
+// routeTags returns the tags documented for a route
+func (app *App) routeTags(route RouteInfo) []string {
+	if len(route.Tags) > 0 || !app.autoTags {
+		return route.Tags
+	}
+	for _, segment := range strings.Split(route.Path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, ":") && segment != "*" {
+			return []string{segment}
+		}
+	}
+	return nil
+}
+
+// AddTag declares a tag with a description and, optionally, a link to
+// external docs. The docs UI lists operations under declared tags in the
+// order they were added, before any undeclared ones. Declaring a tag again
+// replaces its metadata but keeps its position.
+func (app *App) AddTag(name, description, externalDocsURL string) {
+	if name == "" {
+		panic("echonext: tag name must not be empty")
+	}
+	tag := &openapi3.Tag{Name: name, Description: description}
+	if externalDocsURL != "" {
+		tag.ExternalDocs = &openapi3.ExternalDocs{URL: externalDocsURL}
+	}
+
+	if existing := app.spec.Tags.Get(name); existing != nil {
+		*existing = *tag
+		return
+	}
+	app.spec.Tags = append(app.spec.Tags, tag)
+}
+
+// warnUndeclaredTags logs, once per tag, tags used by routes but not declared
+// with AddTag. Apps that declare no tags aren't warned.
+func (app *App) warnUndeclaredTags(routes []RouteInfo) {
+	if len(app.spec.Tags) == 0 {
+		return
+	}
+	for _, route := range routes {
+		for _, name := range app.routeTags(route) {
+			if app.spec.Tags.Get(name) != nil || app.warnedTags[name] {
+				continue
+			}
+			if app.warnedTags == nil {
+				app.warnedTags = make(map[string]bool)
+			}
+			app.warnedTags[name] = true
+			app.Logger.Warnf("echonext: tag %q of %s %s is not declared with AddTag", name, route.Method, route.Path)
+		}
+	}
+}
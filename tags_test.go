@@ -0,0 +1,60 @@
+package echonext_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoTags(t *testing.T) {
+	handler := func(c echo.Context) (TestUser, error) { return TestUser{}, nil }
+
+	app := echonext.New()
+	app.SetAutoTags(true)
+	app.GET("/todos/:id", handler)
+	app.GET("/:tenant/users", handler)
+	app.GET("/orders", handler, echonext.Route{Tags: []string{"Billing"}})
+	app.GET("/", handler)
+
+	spec := app.GenerateOpenAPISpec()
+	assert.Equal(t, []string{"todos"}, spec.Paths["/todos/{id}"].Get.Tags)
+	assert.Equal(t, []string{"users"}, spec.Paths["/{tenant}/users"].Get.Tags)
+	assert.Equal(t, []string{"Billing"}, spec.Paths["/orders"].Get.Tags)
+	assert.Empty(t, spec.Paths["/"].Get.Tags)
+}
+
+func TestDeclaredTags(t *testing.T) {
+	handler := func(c echo.Context) (TestUser, error) { return TestUser{}, nil }
+
+	app := echonext.New()
+	var logs bytes.Buffer
+	app.Logger.SetOutput(&logs)
+	app.Logger.SetLevel(log.WARN)
+
+	app.AddTag("Billing", "Invoices and payments", "https://docs.example.com/billing")
+	app.AddTag("Auth", "Sessions and tokens", "")
+	app.GET("/login", handler, echonext.Route{Tags: []string{"Auth"}})
+	app.GET("/invoices", handler, echonext.Route{Tags: []string{"Billing"}})
+	app.GET("/reports", handler, echonext.Route{Tags: []string{"Reports"}})
+	app.AddTag("Billing", "Invoices, payments and refunds", "")
+
+	spec := app.GenerateOpenAPISpec()
+	if assert.Len(t, spec.Tags, 2) {
+		assert.Equal(t, "Billing", spec.Tags[0].Name, "declaration order is kept")
+		assert.Equal(t, "Invoices, payments and refunds", spec.Tags[0].Description)
+		assert.Nil(t, spec.Tags[0].ExternalDocs)
+		assert.Equal(t, "Auth", spec.Tags[1].Name)
+	}
+	assert.Equal(t, []string{"Reports"}, spec.Paths["/reports"].Get.Tags)
+
+	app.GenerateOpenAPISpec()
+	assert.Equal(t, 1, strings.Count(logs.String(), `tag \"Reports\"`), "undeclared tags are warned about once")
+	assert.NotContains(t, logs.String(), "Billing")
+
+	assert.Panics(t, func() { app.AddTag("", "", "") })
+}
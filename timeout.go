@@ -0,0 +1,55 @@
+package echonext
+
+import (
+	"context"
+	"mime"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetHandlerTimeout sets a deadline on the request context of every typed
+// handler. Timeouts are cooperative: handlers should pass c.Request().Context()
+// to blocking calls and return its error, which is reported as 503.
+// Route.Timeout and SetContentTypeTimeout override the default; zero disables it.
+func (app *App) SetHandlerTimeout(d time.Duration) {
+	app.handlerTimeout = d
+}
+
+// SetContentTypeTimeout overrides the handler timeout for requests of a media
+// type, e.g. a longer deadline for "multipart/form-data" uploads
+func (app *App) SetContentTypeTimeout(mediaType string, d time.Duration) {
+	if app.contentTypeTimeouts == nil {
+		app.contentTypeTimeouts = make(map[string]time.Duration)
+	}
+	app.contentTypeTimeouts[mediaType] = d
+}
+
+// timeoutFor returns the deadline for a request, by precedence of the route,
+// the request content type and the app default
+func (app *App) timeoutFor(c echo.Context, route *Route) time.Duration {
+	if route != nil && route.Timeout != nil {
+		return *route.Timeout
+	}
+	if len(app.contentTypeTimeouts) > 0 {
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		if d, ok := app.contentTypeTimeouts[mediaType]; ok {
+			return d
+		}
+	}
+	return app.handlerTimeout
+}
+
+// withTimeout runs next with the request context bounded by the route's timeout
+func (app *App) withTimeout(route *Route, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		d := app.timeoutFor(c, route)
+		if d <= 0 {
+			return next(c)
+		}
+		ctx, cancel := context.WithTimeout(c.Request().Context(), d)
+		defer cancel()
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
@@ -0,0 +1,60 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// slowHandler waits for d or until the request deadline passes
+func slowHandler(d time.Duration) func(c echo.Context) (TestUser, error) {
+	return func(c echo.Context) (TestUser, error) {
+		select {
+		case <-time.After(d):
+			return TestUser{ID: "1"}, nil
+		case <-c.Request().Context().Done():
+			return TestUser{}, c.Request().Context().Err()
+		}
+	}
+}
+
+func TestHandlerTimeout(t *testing.T) {
+	noTimeout := time.Duration(0)
+
+	app := echonext.New()
+	app.SetHandlerTimeout(20 * time.Millisecond)
+	app.SetContentTypeTimeout(echo.MIMEMultipartForm, time.Second)
+
+	app.POST("/reports", slowHandler(100*time.Millisecond))
+	app.POST("/exports", slowHandler(100*time.Millisecond), echonext.Route{Timeout: &noTimeout})
+
+	serve := func(path, contentType string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(""))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("times out", func(t *testing.T) {
+		rec := serve("/reports", echo.MIMEApplicationJSON)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Request timed out")
+	})
+
+	t.Run("exempt route", func(t *testing.T) {
+		rec := serve("/exports", echo.MIMEApplicationJSON)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("longer timeout for uploads", func(t *testing.T) {
+		rec := serve("/reports", echo.MIMEMultipartForm+"; boundary=xyz")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
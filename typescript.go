@@ -0,0 +1,286 @@
+package echonext
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// GenerateTypeScriptTypes writes TypeScript definitions for the request and
+// response types of the documented routes and the types they refer to, as
+// documented in the spec. Pointer and omitempty fields are optional, pointers
+// may also be null, and oneof rules and enums become unions of literals.
+func (app *App) GenerateTypeScriptTypes(w io.Writer) error {
+	app.specMu.Lock()
+	defer app.specMu.Unlock()
+	defer app.isolateSchemas()()
+
+	var inline []reflect.Type
+	for _, t := range app.exportedTypes() {
+		if app.schemaRef(t).Ref == "" {
+			inline = append(inline, t)
+		}
+	}
+	app.errorSchemaRef()
+
+	declarations := map[string]tsDeclaration{}
+	types := make(map[string]reflect.Type, len(app.componentNames))
+	for t, name := range app.componentNames {
+		types[name] = t
+	}
+	for name, component := range app.spec.Components.Schemas {
+		declarations[name] = tsDeclaration{schema: component.Value, t: types[name]}
+	}
+
+	// Types documented inline, e.g. with SetInlineSchemas, still get a name
+	for _, t := range inline {
+		taken := make(map[string]bool, len(declarations))
+		for name := range declarations {
+			taken[name] = true
+		}
+		name := uniqueID(cleanTypeName(t.Name()), taken)
+		declarations[name] = tsDeclaration{schema: app.generateSchema(t), t: t}
+	}
+
+	names := make([]string, 0, len(declarations))
+	for name := range declarations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := bufio.NewWriter(w)
+	out.WriteString("// Code generated by echonext. DO NOT EDIT.\n")
+	for _, name := range names {
+		d := declarations[name]
+		out.WriteString("\n")
+		writeTSDoc(out, "", d.schema.Description)
+		if isTSInterface(d.schema) {
+			fmt.Fprintf(out, "export interface %s %s\n", name, tsObject(d.schema, d.t, app.naming, ""))
+		} else {
+			fmt.Fprintf(out, "export type %s = %s;\n", name, tsType(&openapi3.SchemaRef{Value: d.schema}, d.t, app.naming, ""))
+		}
+	}
+	return out.Flush()
+}
+
+// tsDeclaration is a named schema and the Go type it documents, if known
+type tsDeclaration struct {
+	schema *openapi3.Schema
+	t      reflect.Type
+}
+
+// tsField is the Go side of a JSON property
+type tsField struct {
+	t        reflect.Type
+	optional bool
+}
+
+// tsIdentifier matches property names that need no quotes
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// isTSInterface reports whether a schema is declared as an interface
+func isTSInterface(schema *openapi3.Schema) bool {
+	return schema.Type == "object" && len(schema.Properties) > 0 && schema.AdditionalProperties.Schema == nil && len(schema.Enum) == 0
+}
+
+// tsType returns the TypeScript type of a schema. t is the Go type it
+// documents, used to tell which properties may be absent, or nil.
+func tsType(ref *openapi3.SchemaRef, t reflect.Type, naming NamingStrategy, indent string) string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name, ok := strings.CutPrefix(ref.Ref, componentSchemaPrefix); ok {
+		return name
+	}
+	schema := ref.Value
+	if schema == nil {
+		return "unknown"
+	}
+
+	// Decorated component references wrap the component in allOf
+	if len(schema.AllOf) == 1 && schema.Type == "" {
+		return tsNullable(tsType(schema.AllOf[0], t, naming, indent), schema.Nullable)
+	}
+
+	if len(schema.Enum) > 0 {
+		literals := make([]string, 0, len(schema.Enum))
+		seen := map[string]bool{}
+		for _, value := range schema.Enum {
+			data, err := json.Marshal(value)
+			if err != nil || seen[string(data)] {
+				continue
+			}
+			seen[string(data)] = true
+			literals = append(literals, string(data))
+		}
+		if schema.Nullable && !seen["null"] {
+			literals = append(literals, "null")
+		}
+		return strings.Join(literals, " | ")
+	}
+
+	var typ string
+	switch schema.Type {
+	case "string":
+		typ = "string"
+	case "integer", "number":
+		typ = "number"
+	case "boolean":
+		typ = "boolean"
+	case "array":
+		var elem reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elem = t.Elem()
+		}
+		typ = tsType(schema.Items, elem, naming, indent)
+		if !strings.HasPrefix(typ, "{") && strings.Contains(typ, " | ") {
+			typ = "(" + typ + ")"
+		}
+		typ += "[]"
+	case "object":
+		switch {
+		case len(schema.Properties) > 0:
+			typ = tsObject(schema, t, naming, indent)
+		case schema.AdditionalProperties.Schema != nil:
+			var elem reflect.Type
+			if t != nil && t.Kind() == reflect.Map {
+				elem = t.Elem()
+			}
+			typ = "Record<string, " + tsType(schema.AdditionalProperties.Schema, elem, naming, indent) + ">"
+		default:
+			typ = "Record<string, unknown>"
+		}
+	default:
+		typ = "unknown"
+	}
+	return tsNullable(typ, schema.Nullable)
+}
+
+// tsNullable adds null to a type the schema allows to be null
+func tsNullable(typ string, nullable bool) string {
+	if !nullable || strings.HasSuffix(typ, " | null") {
+		return typ
+	}
+	return typ + " | null"
+}
+
+// tsObject returns an object type literal for a schema with properties
+func tsObject(schema *openapi3.Schema, t reflect.Type, naming NamingStrategy, indent string) string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var fields map[string]tsField
+	if t != nil && t.Kind() == reflect.Struct {
+		fields = tsFields(t, naming)
+	}
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	inner := indent + "  "
+	for _, name := range names {
+		property := schema.Properties[name]
+		field, known := fields[name]
+		optional := !required[name]
+		if known {
+			optional = field.optional && !required[name]
+		}
+
+		key := name
+		if !tsIdentifier.MatchString(name) {
+			key = fmt.Sprintf("%q", name)
+		}
+		if optional {
+			key += "?"
+		}
+		if property.Value != nil {
+			writeTSDoc(&b, inner, property.Value.Description)
+		}
+		fmt.Fprintf(&b, "%s%s: %s;\n", inner, key, tsType(property, field.t, naming, inner))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsFields maps the JSON names of a struct's properties to their fields,
+// flattening embedded structs as buildSchema does. Pointer and omitempty
+// fields, and those of embedded pointers, may be absent.
+func tsFields(t reflect.Type, naming NamingStrategy) map[string]tsField {
+	fields := map[string]tsField{}
+	var promoted []map[string]tsField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := naming.fieldName(field)
+		if !ok {
+			continue
+		}
+		if isEmbeddedStruct(field) {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			inner := tsFields(embedded, naming)
+			if field.Type.Kind() == reflect.Ptr {
+				for name, f := range inner {
+					f.optional = true
+					inner[name] = f
+				}
+			}
+			promoted = append(promoted, inner)
+			continue
+		}
+
+		omitempty := false
+		for _, option := range strings.Split(field.Tag.Get("json"), ",")[1:] {
+			if option == "omitempty" && field.Type.Kind() != reflect.Struct {
+				omitempty = true
+			}
+		}
+		optional := (omitempty || field.Type.Kind() == reflect.Ptr) && !requiredRule(field.Tag.Get("validate"))
+		fields[name] = tsField{t: field.Type, optional: optional}
+	}
+
+	// Fields declared here shadow promoted ones
+	for _, inner := range promoted {
+		for name, f := range inner {
+			if _, taken := fields[name]; !taken {
+				fields[name] = f
+			}
+		}
+	}
+	return fields
+}
+
+// writeTSDoc writes a description as a doc comment
+func writeTSDoc(w io.StringWriter, indent, description string) {
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(description, "*/", "*\\/")
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		w.WriteString(indent + "/** " + description + " */\n")
+		return
+	}
+	w.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		w.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	w.WriteString(indent + " */\n")
+}
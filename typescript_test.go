@@ -0,0 +1,74 @@
+package echonext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Ticket struct {
+	*Audit
+	ID       string         `json:"id"`
+	Status   string         `json:"status" validate:"required,oneof=open closed"`
+	Assignee *TestUser      `json:"assignee"`
+	Note     *string        `json:"note,omitempty"`
+	Tags     []string       `json:"tags,omitempty"`
+	Labels   map[string]int `json:"labels"`
+	Meta     struct {
+		Version int `json:"version"`
+	} `json:"meta"`
+	Parent *Category `json:"parent,omitempty"`
+	Secret string    `json:"-"`
+}
+
+func TestGenerateTypeScriptTypes(t *testing.T) {
+	app := echonext.New()
+	app.GET("/tickets", func(c echo.Context) ([]Ticket, error) { return nil, nil })
+	app.POST("/users", func(c echo.Context, req CreateUserRequest) (*TestUser, error) { return &TestUser{}, nil })
+
+	var b strings.Builder
+	require.NoError(t, app.GenerateTypeScriptTypes(&b))
+	ts := b.String()
+
+	assert.True(t, strings.HasPrefix(ts, "// Code generated by echonext. DO NOT EDIT.\n"))
+	assert.Contains(t, ts, `export interface Ticket {
+  assignee?: TestUser | null;
+  created_by?: string;
+  id: string;
+  labels: Record<string, number>;
+  meta: {
+    version: number;
+  };
+  note?: string | null;
+  parent?: Category | null;
+  status: "open" | "closed";
+  tags?: string[];
+}`)
+	assert.Contains(t, ts, `export interface CreateUserRequest {
+  email: string;
+  name: string;
+}`)
+	assert.Contains(t, ts, "export interface TestUser {")
+	assert.Contains(t, ts, "  children: Category[];\n")
+	assert.Contains(t, ts, "export interface ErrorResponse {")
+	assert.Contains(t, ts, "  details?: {\n")
+	assert.NotContains(t, ts, "Secret")
+
+	// Types stay declared when schemas are inlined in the spec
+	app.SetInlineSchemas(true)
+	b.Reset()
+	require.NoError(t, app.GenerateTypeScriptTypes(&b))
+	assert.Contains(t, b.String(), "export interface Ticket {")
+	assert.Contains(t, b.String(), "  assignee?: {\n")
+	assert.Contains(t, b.String(), "export interface Category {")
+	assert.Contains(t, app.GenerateOpenAPISpec().Components.Schemas, "Category")
+	assert.NotContains(t, app.GenerateOpenAPISpec().Components.Schemas, "Ticket")
+}
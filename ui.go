@@ -0,0 +1,145 @@
+package echonext
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SwaggerUIConfig customizes the Swagger UI page
+type SwaggerUIConfig struct {
+	HeadHTML   string // Inserted at the end of <head>, e.g. analytics or styles
+	BodyHTML   string // Inserted at the end of <body>, after Swagger UI is initialized
+	FaviconURL string
+}
+
+// ServeSwaggerUIWithConfig serves Swagger UI with custom HTML snippets and favicon
+func (app *App) ServeSwaggerUIWithConfig(path string, specPath string, config SwaggerUIConfig) {
+	favicon := ""
+	if config.FaviconURL != "" {
+		favicon = fmt.Sprintf(`<link rel="icon" href="%s">`, html.EscapeString(config.FaviconURL))
+	}
+
+	app.Echo.GET(path, func(c echo.Context) error {
+		page := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>%s - API Documentation</title>
+    <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+    %s
+    %s
+</head>
+<body>
+    <div id="swagger-ui"></div>
+    <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+    <script>
+        window.onload = function() {
+            SwaggerUIBundle({
+                url: "%s",
+                dom_id: '#swagger-ui',
+                presets: [
+                    SwaggerUIBundle.presets.apis,
+                    SwaggerUIBundle.presets.standalone
+                ],
+                layout: "BaseLayout",
+                deepLinking: true
+            });
+        }
+    </script>
+    %s
+</body>
+</html>`, html.EscapeString(app.spec.Info.Title), favicon, config.HeadHTML, specPath, config.BodyHTML)
+		return c.HTML(http.StatusOK, page)
+	})
+}
+
+// DefaultRapiDocScriptURL is the CDN build of RapiDoc used unless overridden
+const DefaultRapiDocScriptURL = "https://unpkg.com/rapidoc/dist/rapidoc-min.js"
+
+// RapiDocConfig customizes the RapiDoc page
+type RapiDocConfig struct {
+	Theme     string // "light" (default) or "dark"
+	Layout    string // "row" (default) or "column"
+	ScriptURL string // Self-hosted rapidoc-min.js for offline use
+}
+
+// ServeRapiDoc serves RapiDoc for API documentation
+func (app *App) ServeRapiDoc(path string, specPath string, opts ...RapiDocConfig) {
+	config := RapiDocConfig{}
+	if len(opts) > 0 {
+		config = opts[0]
+	}
+	if config.Theme == "" {
+		config.Theme = "light"
+	}
+	if config.Layout == "" {
+		config.Layout = "row"
+	}
+	if config.ScriptURL == "" {
+		config.ScriptURL = DefaultRapiDocScriptURL
+	}
+
+	app.Echo.GET(path, func(c echo.Context) error {
+		page := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>%s - API Documentation</title>
+    <meta charset="utf-8">
+    <script type="module" src="%s"></script>
+</head>
+<body>
+    <rapi-doc spec-url="%s" theme="%s" layout="%s"></rapi-doc>
+</body>
+</html>`,
+			html.EscapeString(app.spec.Info.Title),
+			html.EscapeString(config.ScriptURL),
+			html.EscapeString(specPath),
+			html.EscapeString(config.Theme),
+			html.EscapeString(config.Layout))
+		return c.HTML(http.StatusOK, page)
+	})
+}
+
+// DefaultReDocScriptURL is the CDN build of the ReDoc standalone bundle used unless overridden
+const DefaultReDocScriptURL = "https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"
+
+// ReDocConfig customizes the ReDoc page
+type ReDocConfig struct {
+	ScriptURL string // Self-hosted redoc.standalone.js for air-gapped deployments
+}
+
+// ServeReDoc serves ReDoc for API documentation
+func (app *App) ServeReDoc(path string, specPath string, opts ...ReDocConfig) {
+	config := ReDocConfig{}
+	if len(opts) > 0 {
+		config = opts[0]
+	}
+	if config.ScriptURL == "" {
+		config.ScriptURL = DefaultReDocScriptURL
+	}
+
+	app.Echo.GET(path, func(c echo.Context) error {
+		page := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>%s - API Documentation</title>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+    <redoc spec-url="%s"></redoc>
+    <script src="%s"></script>
+</body>
+</html>`,
+			html.EscapeString(app.spec.Info.Title),
+			html.EscapeString(specPath),
+			html.EscapeString(config.ScriptURL))
+		return c.HTML(http.StatusOK, page)
+	})
+}
@@ -0,0 +1,87 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeSwaggerUIWithConfig(t *testing.T) {
+	app := echonext.New()
+	app.ServeSwaggerUIWithConfig("/docs", "/openapi.json", echonext.SwaggerUIConfig{
+		HeadHTML:   `<script src="https://analytics.example.com/a.js"></script>`,
+		BodyHTML:   `<footer>Acme Corp</footer>`,
+		FaviconURL: "/static/favicon.png",
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	page := rec.Body.String()
+	assert.Contains(t, page, `<link rel="icon" href="/static/favicon.png">`)
+	assert.Contains(t, page, `url: "/openapi.json"`)
+
+	head := page[:strings.Index(page, "</head>")]
+	assert.Contains(t, head, `<script src="https://analytics.example.com/a.js"></script>`)
+
+	// Body snippets come after the Swagger UI bootstrap script
+	footer := strings.Index(page, "<footer>Acme Corp</footer>")
+	assert.Greater(t, footer, strings.Index(page, "SwaggerUIBundle({"))
+	assert.Less(t, footer, strings.Index(page, "</body>"))
+}
+
+func TestServeRapiDoc(t *testing.T) {
+	app := echonext.New()
+	app.ServeRapiDoc("/rapidoc", "/openapi.json")
+	app.ServeRapiDoc("/rapidoc-offline", "/openapi.json", echonext.RapiDocConfig{
+		Theme:     "dark",
+		Layout:    "column",
+		ScriptURL: "/static/rapidoc-min.js",
+	})
+
+	get := func(path string) string {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	page := get("/rapidoc")
+	assert.Contains(t, page, `<rapi-doc spec-url="/openapi.json" theme="light" layout="row">`)
+	assert.Contains(t, page, echonext.DefaultRapiDocScriptURL)
+
+	page = get("/rapidoc-offline")
+	assert.Contains(t, page, `<rapi-doc spec-url="/openapi.json" theme="dark" layout="column">`)
+	assert.Contains(t, page, `src="/static/rapidoc-min.js"`)
+	assert.NotContains(t, page, "unpkg.com")
+}
+
+func TestServeReDoc(t *testing.T) {
+	app := echonext.New()
+	app.SetInfo("Todo API", "1.0.0", "")
+	app.ServeReDoc("/redoc", "/openapi.json")
+	app.ServeReDoc("/redoc-offline", "/openapi.json", echonext.ReDocConfig{
+		ScriptURL: "/static/redoc.standalone.js",
+	})
+
+	get := func(path string) string {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	page := get("/redoc")
+	assert.Contains(t, page, "<title>Todo API - API Documentation</title>")
+	assert.Contains(t, page, `<redoc spec-url="/openapi.json"></redoc>`)
+	assert.Contains(t, page, echonext.DefaultReDocScriptURL)
+
+	page = get("/redoc-offline")
+	assert.Contains(t, page, `src="/static/redoc.standalone.js"`)
+	assert.NotContains(t, page, "cdn.redoc.ly")
+}
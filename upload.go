@@ -0,0 +1,78 @@
+package echonext
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// fileField is a request field bound from uploaded multipart files
+type fileField struct {
+	index    int
+	name     string
+	multiple bool // []*multipart.FileHeader
+}
+
+// fileFields finds *multipart.FileHeader and []*multipart.FileHeader fields
+func fileFields(t reflect.Type) []fileField {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []fileField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("form") == "-" {
+			continue
+		}
+		switch {
+		case isFileType(field.Type):
+			fields = append(fields, fileField{index: i, name: formFieldName(field)})
+		case field.Type.Kind() == reflect.Slice && isFileType(field.Type.Elem()):
+			fields = append(fields, fileField{index: i, name: formFieldName(field), multiple: true})
+		}
+	}
+	return fields
+}
+
+// isFileType reports whether t is an uploaded file
+func isFileType(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem() == fileHeaderType
+}
+
+// bindFiles fills file fields from a multipart request. Requests of other
+// content types leave them nil.
+func bindFiles(c echo.Context, target reflect.Value, fields []fileField) error {
+	ctype := c.Request().Header.Get(echo.HeaderContentType)
+	if len(fields) == 0 || !strings.HasPrefix(ctype, echo.MIMEMultipartForm) {
+		return nil
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return err
+	}
+
+	target = reflect.Indirect(target)
+	for _, field := range fields {
+		files := form.File[field.name]
+		if len(files) == 0 {
+			continue
+		}
+		if field.multiple {
+			target.Field(field.index).Set(reflect.ValueOf(files))
+		} else {
+			target.Field(field.index).Set(reflect.ValueOf(files[0]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type UploadRequest struct {
+	Title       string                  `form:"title" validate:"required"`
+	Avatar      *multipart.FileHeader   `form:"avatar" validate:"required"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+}
+
+type UploadResponse struct {
+	Title       string   `json:"title"`
+	Avatar      string   `json:"avatar"`
+	Contents    string   `json:"contents"`
+	Attachments []string `json:"attachments"`
+}
+
+func TestFileUploads(t *testing.T) {
+	app := echonext.New()
+	app.POST("/uploads", func(c echo.Context, req UploadRequest) (UploadResponse, error) {
+		file, err := req.Avatar.Open()
+		if err != nil {
+			return UploadResponse{}, err
+		}
+		defer file.Close()
+		contents, _ := io.ReadAll(file)
+
+		response := UploadResponse{Title: req.Title, Avatar: req.Avatar.Filename, Contents: string(contents)}
+		for _, attachment := range req.Attachments {
+			response.Attachments = append(response.Attachments, attachment.Filename)
+		}
+		return response, nil
+	})
+
+	upload := func(files map[string][]string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		_ = writer.WriteField("title", "Holiday")
+		for field, names := range files {
+			for _, name := range names {
+				part, _ := writer.CreateFormFile(field, name)
+				_, _ = part.Write([]byte("data:" + name))
+			}
+		}
+		_ = writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/uploads", &body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("binds files and fields", func(t *testing.T) {
+		rec := upload(map[string][]string{
+			"avatar":      {"me.png"},
+			"attachments": {"a.txt", "b.txt"},
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response echonext.Response[UploadResponse]
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, UploadResponse{
+			Title:       "Holiday",
+			Avatar:      "me.png",
+			Contents:    "data:me.png",
+			Attachments: []string{"a.txt", "b.txt"},
+		}, response.Data)
+	})
+
+	t.Run("missing required file", func(t *testing.T) {
+		rec := upload(nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "avatar is required")
+	})
+
+	t.Run("documented as multipart", func(t *testing.T) {
+		content := app.GenerateOpenAPISpec().Paths["/uploads"].Post.RequestBody.Value.Content
+		assert.NotContains(t, content, "application/json")
+		schema := content[echo.MIMEMultipartForm].Schema.Value
+
+		avatar := schema.Properties["avatar"].Value
+		assert.Equal(t, "string", avatar.Type)
+		assert.Equal(t, "binary", avatar.Format)
+		assert.False(t, avatar.Nullable)
+
+		attachments := schema.Properties["attachments"].Value
+		assert.Equal(t, "array", attachments.Type)
+		assert.Equal(t, "binary", attachments.Items.Value.Format)
+		assert.ElementsMatch(t, []string{"title", "avatar"}, schema.Required)
+	})
+}
@@ -0,0 +1,32 @@
+package echonext
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetMaxURLLength rejects requests whose request URI (path and query) is
+// longer than n bytes with 414 URI Too Long, before routing. The default is
+// unlimited; n <= 0 removes the limit.
+func (app *App) SetMaxURLLength(n int) {
+	if !app.urlLimitInstalled {
+		app.urlLimitInstalled = true
+		app.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				if limit := app.maxURLLength; limit > 0 && len(requestURI(c.Request())) > limit {
+					return errorResponse(c, http.StatusRequestURITooLong, "URI too long")
+				}
+				return next(c)
+			}
+		})
+	}
+	app.maxURLLength = n
+}
+
+func requestURI(req *http.Request) string {
+	if req.RequestURI != "" {
+		return req.RequestURI
+	}
+	return req.URL.RequestURI()
+}
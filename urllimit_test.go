@@ -0,0 +1,38 @@
+package echonext_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxURLLength(t *testing.T) {
+	app := echonext.New()
+	app.SetMaxURLLength(64)
+	app.GET("/search", func(c echo.Context) ([]TestUser, error) {
+		return []TestUser{{ID: "1"}}, nil
+	})
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/search?q=john").Code)
+
+	rec := serve("/search?q=" + strings.Repeat("a", 100))
+	assert.Equal(t, http.StatusRequestURITooLong, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"error":"URI too long"`)
+
+	// Rejected before routing, even for unknown paths
+	assert.Equal(t, http.StatusRequestURITooLong, serve("/"+strings.Repeat("a", 100)).Code)
+
+	app.SetMaxURLLength(0)
+	assert.Equal(t, http.StatusOK, serve("/search?q="+strings.Repeat("a", 100)).Code)
+}
@@ -0,0 +1,50 @@
+package echonext
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// URL builds the path of the route registered with Route.Name, substituting
+// its path parameters. Every parameter must be provided.
+func (app *App) URL(name string, params map[string]string) (string, error) {
+	path, ok := app.routeNames[name]
+	if !ok {
+		return "", fmt.Errorf("echonext: unknown route name %q", name)
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		var param string
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			param = segment[1:]
+		case segment == "*":
+			param = "*"
+		default:
+			continue
+		}
+		value, ok := params[param]
+		if !ok {
+			return "", fmt.Errorf("echonext: route %q is missing path parameter %q", name, param)
+		}
+		if param == "*" {
+			segments[i] = value
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// registerRouteName records the path of a named route
+func (app *App) registerRouteName(name, path string) {
+	if existing, ok := app.routeNames[name]; ok && existing != path {
+		panic(fmt.Sprintf("echonext: route name %q is already used for %s", name, existing))
+	}
+	if app.routeNames == nil {
+		app.routeNames = make(map[string]string)
+	}
+	app.routeNames[name] = path
+}
@@ -0,0 +1,40 @@
+package echonext_test
+
+import (
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteURL(t *testing.T) {
+	handler := func(c echo.Context) (TestUser, error) { return TestUser{}, nil }
+
+	app := echonext.New()
+	app.GET("/todos/:id", handler, echonext.Route{Name: "getTodo"})
+	app.GET("/users/:userId/todos/:id", handler, echonext.Route{Name: "getUserTodo"})
+	app.GET("/files/*", handler, echonext.Route{Name: "getFile"})
+
+	url, err := app.URL("getTodo", map[string]string{"id": "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/todos/123", url)
+
+	url, err = app.URL("getUserTodo", map[string]string{"userId": "a b", "id": "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/users/a%20b/todos/7", url)
+
+	url, err = app.URL("getFile", map[string]string{"*": "docs/readme.md"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/files/docs/readme.md", url)
+
+	_, err = app.URL("getUserTodo", map[string]string{"id": "7"})
+	assert.EqualError(t, err, `echonext: route "getUserTodo" is missing path parameter "userId"`)
+
+	_, err = app.URL("deleteTodo", nil)
+	assert.EqualError(t, err, `echonext: unknown route name "deleteTodo"`)
+
+	assert.Panics(t, func() {
+		app.GET("/tasks/:id", handler, echonext.Route{Name: "getTodo"})
+	})
+}
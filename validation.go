@@ -0,0 +1,297 @@
+package echonext
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-playground/validator/v10"
+)
+
+// SetValidator replaces the validator used for requests, e.g. one with
+// custom validations registered
+func (app *App) SetValidator(v *validator.Validate) {
+	app.validator = v
+}
+
+// Validator returns the validator used for requests, so custom validations
+// can be registered on it
+func (app *App) Validator() *validator.Validate {
+	return app.validator
+}
+
+// RegisterTagSchema documents a custom validate tag. apply is called on the
+// schema of every field using the tag, after built-in tags are applied:
+//
+//	app.RegisterTagSchema("slug", func(s *openapi3.Schema) { s.Pattern = "^[a-z0-9-]+$" })
+func (app *App) RegisterTagSchema(tag string, apply func(s *openapi3.Schema)) {
+	if app.tagSchemas == nil {
+		app.tagSchemas = make(map[string]func(s *openapi3.Schema))
+	}
+	app.tagSchemas[tag] = apply
+	app.invalidateSchemas()
+}
+
+// boundRules are the validate rules documented as length, item count or
+// value bounds depending on the field's type
+var boundRules = map[string]bool{
+	"min": true, "max": true, "gte": true, "lte": true, "gt": true, "lt": true, "len": true,
+}
+
+// formatRules map validate rules to the OpenAPI format they imply
+var formatRules = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"url":      "uri",
+	"http_url": "uri",
+	"uri":      "uri",
+}
+
+// patternRules map validate rules to the pattern the validator checks
+var patternRules = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// applyPattern documents a rule constraining the characters of a string,
+// such as alphanum or contains=@, as a pattern. A schema has one pattern, so
+// the last such rule wins.
+func applyPattern(schema *openapi3.Schema, rule string) {
+	if schema.Type != "string" {
+		return
+	}
+	if pattern, ok := patternRules[rule]; ok {
+		schema.Pattern = pattern
+		return
+	}
+	name, value, ok := strings.Cut(rule, "=")
+	if !ok || value == "" {
+		return
+	}
+	switch name {
+	case "contains":
+		schema.Pattern = regexp.QuoteMeta(value)
+	case "startswith":
+		schema.Pattern = "^" + regexp.QuoteMeta(value)
+	case "endswith":
+		schema.Pattern = regexp.QuoteMeta(value) + "$"
+	}
+}
+
+// applyBound documents a bound rule such as min=3 or gte=0. Like the
+// validator, strings are bounded by length, lists by item count and numbers
+// by value, so the schema type decides the keyword. Exclusive rules become
+// inclusive length bounds or exclusive numeric bounds.
+func (app *App) applyBound(schema *openapi3.Schema, field, rule, value string) {
+	switch schema.Type {
+	case "string", "array":
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return
+		}
+		lower, upper := false, false
+		switch rule {
+		case "min", "gte":
+			lower = true
+		case "gt":
+			n, lower = n+1, true
+		case "max", "lte":
+			upper = true
+		case "lt":
+			if n == 0 {
+				return
+			}
+			n, upper = n-1, true
+		case "len":
+			lower, upper = true, true
+		}
+
+		isString := schema.Type == "string"
+		if lower && isString {
+			schema.MinLength = n
+		} else if lower {
+			schema.MinItems = n
+		}
+		if upper && isString {
+			if n == 0 && rule != "len" {
+				app.Logger.Warnf("echonext: field %s has %s=%s, which only allows empty strings", field, rule, value)
+			}
+			schema.MaxLength = &n
+		} else if upper {
+			schema.MaxItems = &n
+		}
+	case "integer", "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return
+		}
+		switch rule {
+		case "min", "gte":
+			schema.Min = &f
+		case "gt":
+			schema.Min, schema.ExclusiveMin = &f, true
+		case "max", "lte":
+			schema.Max = &f
+		case "lt":
+			schema.Max, schema.ExclusiveMax = &f, true
+		case "len":
+			schema.Min, schema.Max = &f, &f
+		}
+	}
+}
+
+// applyDatetime documents a datetime=layout rule as a date or date-time
+// format when the layout is one of the RFC 3339 forms, and otherwise notes
+// the Go layout as an extension
+func applyDatetime(schema *openapi3.Schema, layout string) {
+	switch layout {
+	case time.RFC3339, time.RFC3339Nano:
+		schema.Format = "date-time"
+	case time.DateOnly:
+		schema.Format = "date"
+	default:
+		setExtension(&schema.Extensions, "x-datetime-layout", layout)
+	}
+}
+
+// validationErrors explains each failed validation rule: the field's name as
+// the client sent it, the rule and its parameter, and a readable message
+func validationErrors(err error, t reflect.Type, naming NamingStrategy) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	details := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		name, in, field := requestField(t, fe.StructNamespace(), naming)
+		detail := FieldError{
+			Field: name,
+			In:    in,
+			Tag:   fe.Tag(),
+			Param: fe.Param(),
+		}
+		if fe.Tag() != "required" {
+			detail.Value = fmt.Sprint(fe.Value())
+			if isSensitive(field) {
+				detail.Value = RedactedValue
+			}
+		}
+		detail.Message = validationMessage(name, fe.Tag(), fe.Param(), fe.Kind(), detail.Value)
+		details = append(details, detail)
+	}
+	return details
+}
+
+// validationMessage describes a failed rule in words
+func validationMessage(name, tag, param string, kind reflect.Kind, value string) string {
+	// Length rules count characters in strings and items in lists
+	unit := ""
+	switch kind {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch tag {
+	case "required":
+		return fmt.Sprintf("%s is required", name)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s, got %q", name, param, value)
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s%s", name, param, unit)
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s%s", name, param, unit)
+	case "gt":
+		return fmt.Sprintf("%s must be more than %s%s", name, param, unit)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s%s", name, param, unit)
+	case "len", "eq":
+		if unit == "" {
+			return fmt.Sprintf("%s must equal %s", name, param)
+		}
+		return fmt.Sprintf("%s must be exactly %s%s", name, param, unit)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", name)
+	case "uuid", "uuid3", "uuid4", "uuid5":
+		return fmt.Sprintf("%s must be a valid UUID", name)
+	case "url", "http_url", "uri":
+		return fmt.Sprintf("%s must be a valid URL", name)
+	case "datetime":
+		return fmt.Sprintf("%s must be a time in the layout %s", name, param)
+	}
+	if param != "" {
+		return fmt.Sprintf("%s failed the %s=%s rule", name, tag, param)
+	}
+	return fmt.Sprintf("%s failed the %s rule", name, tag)
+}
+
+// requestField resolves a validator struct namespace such as
+// "CreateOrderRequest.Items[0].SKU" to the name the client used for the
+// field, where it was sent, and the struct field itself
+func requestField(t reflect.Type, namespace string, naming NamingStrategy) (string, string, reflect.StructField) {
+	parts := strings.Split(namespace, ".")[1:]
+	names := make([]string, 0, len(parts))
+	in := "body"
+	var field reflect.StructField
+
+	for _, part := range parts {
+		goName, index := part, ""
+		if bracket := strings.Index(part, "["); bracket >= 0 {
+			goName, index = part[:bracket], part[bracket:]
+		}
+
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		f, ok := t.FieldByName(goName)
+		if !ok {
+			names = append(names, part)
+			continue
+		}
+		field = f
+		t = f.Type
+
+		// Embedded structs are flattened, as in JSON
+		if f.Anonymous && index == "" && f.Tag.Get("json") == "" && f.Tag.Get("query") == "" {
+			continue
+		}
+
+		name := goName
+		if formName := strings.Split(f.Tag.Get("form"), ",")[0]; formName != "" && formName != "-" && f.Tag.Get("json") == "" {
+			name = formName
+		} else if jsonName, ok := naming.fieldName(f); ok {
+			name = jsonName
+		}
+		// Top-level fields may be bound from outside the body, and fields
+		// nested in query structs keep their query names
+		if len(names) > 0 && in == "query" {
+			if tagName := f.Tag.Get("query"); tagName != "" && tagName != "-" {
+				name = tagName
+			}
+		}
+		if len(names) == 0 {
+			for _, source := range []struct{ tag, in string }{{"query", "query"}, {"param", "path"}, {"header", "header"}} {
+				if tagName := f.Tag.Get(source.tag); tagName != "" && tagName != "-" {
+					name, in = tagName, source.in
+					break
+				}
+			}
+		}
+		names = append(names, name+index)
+	}
+	return strings.Join(names, "."), in, field
+}
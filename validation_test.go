@@ -0,0 +1,280 @@
+package echonext_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type ListTodosRequest struct {
+	Sort  string `query:"sort" validate:"omitempty,oneof=created_at updated_at title"`
+	Limit int    `query:"limit" validate:"omitempty,max=100"`
+}
+
+func TestOneOfValidationErrors(t *testing.T) {
+	app := echonext.New()
+	app.GET("/todos", func(c echo.Context, req ListTodosRequest) ([]TestUser, error) {
+		return []TestUser{{ID: "1"}}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/todos?sort=bogus", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Error, "Validation failed")
+	assert.Equal(t, []echonext.FieldError{{
+		Field:   "sort",
+		In:      "query",
+		Tag:     "oneof",
+		Param:   "created_at updated_at title",
+		Value:   "bogus",
+		Message: `sort must be one of: created_at updated_at title, got "bogus"`,
+	}}, response.Details)
+}
+
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"min=8" sensitive:"true"`
+	Address  struct {
+		Zip string `json:"zip_code" validate:"len=5"`
+	} `json:"address"`
+	Roles []string `json:"roles" validate:"max=2,dive,oneof=admin user"`
+	Age   int      `json:"age" validate:"gte=18"`
+}
+
+func TestStructuredValidationErrors(t *testing.T) {
+	app := echonext.New()
+	app.POST("/signup", func(c echo.Context, req RegisterRequest) (TestUser, error) {
+		return TestUser{ID: "1"}, nil
+	})
+
+	body := `{"password":"hunter2","address":{"zip_code":"123"},"roles":["admin","root"],"age":16}`
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var response echonext.Response[any]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Error, "Validation failed")
+	assert.Equal(t, []echonext.FieldError{
+		{Field: "email", In: "body", Tag: "required", Message: "email is required"},
+		{Field: "password", In: "body", Tag: "min", Param: "8", Value: "***", Message: "password must be at least 8 characters"},
+		{Field: "address.zip_code", In: "body", Tag: "len", Param: "5", Value: "123", Message: "address.zip_code must be exactly 5 characters"},
+		{Field: "roles[1]", In: "body", Tag: "oneof", Param: "admin user", Value: "root", Message: `roles[1] must be one of: admin user, got "root"`},
+		{Field: "age", In: "body", Tag: "gte", Param: "18", Value: "16", Message: "age must be at least 18"},
+	}, response.Details)
+	assert.NotContains(t, rec.Body.String(), "hunter2")
+}
+
+type CreateArticleRequest struct {
+	Title string `json:"title" validate:"required,max=200"`
+	Slug  string `json:"slug" validate:"required,slug,max=64"`
+}
+
+func TestRegisterTagSchema(t *testing.T) {
+	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+
+	app := echonext.New()
+	assert.NoError(t, app.Validator().RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slug.MatchString(fl.Field().String())
+	}))
+	app.RegisterTagSchema("slug", func(s *openapi3.Schema) {
+		s.Pattern = slug.String()
+	})
+
+	app.POST("/articles", func(c echo.Context, req CreateArticleRequest) (CreateArticleRequest, error) {
+		return req, nil
+	})
+
+	t.Run("documented", func(t *testing.T) {
+		schema := app.GenerateOpenAPISpec().Paths["/articles"].Post.RequestBody.Value.Content["application/json"].Schema.Value
+		field := schema.Properties["slug"].Value
+		assert.Equal(t, `^[a-z0-9-]+$`, field.Pattern)
+		assert.Equal(t, uint64(64), *field.MaxLength)
+		assert.Empty(t, schema.Properties["title"].Value.Pattern)
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{"title":"Hello","slug":"Not A Slug"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+type BoundsRequest struct {
+	Nickname string   `json:"nickname" validate:"min=0,max=30"`
+	Code     string   `json:"code" validate:"max=0"`
+	Bio      string   `json:"bio" validate:"max=1000000"`
+	Age      int      `json:"age" validate:"min=0,max=150"`
+	Balance  float64  `json:"balance" validate:"min=-100.5,max=1e18"`
+	Labels   []string `json:"labels" validate:"max=5,dive,min=2,max=20"`
+	Handle   string   `json:"handle" validate:"min=-1"`
+}
+
+func TestMinMaxBounds(t *testing.T) {
+	app := echonext.New()
+	var logs bytes.Buffer
+	app.Logger.SetOutput(&logs)
+	app.Logger.SetLevel(log.WARN)
+
+	app.POST("/bounds", func(c echo.Context, req BoundsRequest) (BoundsRequest, error) {
+		return req, nil
+	})
+	props := app.GenerateOpenAPISpec().Paths["/bounds"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties
+	uintPtr := func(n uint64) *uint64 { return &n }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	t.Run("min=0", func(t *testing.T) {
+		assert.Equal(t, uint64(0), props["nickname"].Value.MinLength)
+		assert.Equal(t, uintPtr(30), props["nickname"].Value.MaxLength)
+		assert.Equal(t, floatPtr(0), props["age"].Value.Min)
+	})
+
+	t.Run("max=0", func(t *testing.T) {
+		assert.Equal(t, uintPtr(0), props["code"].Value.MaxLength)
+		assert.Contains(t, logs.String(), "field code has max=0")
+		assert.NotContains(t, logs.String(), "field nickname")
+	})
+
+	t.Run("large values", func(t *testing.T) {
+		assert.Equal(t, uintPtr(1000000), props["bio"].Value.MaxLength)
+		assert.Equal(t, floatPtr(1e18), props["balance"].Value.Max)
+		assert.Equal(t, floatPtr(-100.5), props["balance"].Value.Min)
+	})
+
+	t.Run("kinds don't cross", func(t *testing.T) {
+		assert.Nil(t, props["nickname"].Value.Max)
+		assert.Nil(t, props["age"].Value.MaxLength)
+		assert.Equal(t, uint64(0), props["age"].Value.MinLength)
+
+		labels := props["labels"].Value
+		assert.Equal(t, uintPtr(5), labels.MaxItems)
+		assert.Equal(t, uint64(0), labels.MinItems)
+		assert.Nil(t, labels.MaxLength)
+	})
+
+	t.Run("negative length ignored", func(t *testing.T) {
+		assert.Equal(t, uint64(0), props["handle"].Value.MinLength)
+	})
+}
+
+type ConstraintsRequest struct {
+	ID       string   `json:"id" validate:"uuid4"`
+	Website  string   `json:"website" validate:"omitempty,url"`
+	PIN      string   `json:"pin" validate:"len=4"`
+	Quantity int      `json:"quantity" validate:"gte=1,lte=99"`
+	Discount float64  `json:"discount" validate:"gt=0,lt=1"`
+	Name     string   `json:"name" validate:"gte=2,lte=50"`
+	Tags     []string `json:"tags" validate:"gt=0,lt=11"`
+	Birthday string   `json:"birthday" validate:"datetime=2006-01-02"`
+	StartsAt string   `json:"starts_at" validate:"datetime=2006-01-02T15:04:05Z07:00"`
+	Clock    string   `json:"clock" validate:"datetime=15:04"`
+}
+
+func TestValidationConstraints(t *testing.T) {
+	app := echonext.New()
+	app.POST("/constraints", func(c echo.Context, req ConstraintsRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+	props := app.GenerateOpenAPISpec().Paths["/constraints"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties
+	uintPtr := func(n uint64) *uint64 { return &n }
+	floatPtr := func(f float64) *float64 { return &f }
+
+	t.Run("formats", func(t *testing.T) {
+		assert.Equal(t, "uuid", props["id"].Value.Format)
+		assert.Equal(t, "uri", props["website"].Value.Format)
+		assert.Equal(t, "date", props["birthday"].Value.Format)
+		assert.Equal(t, "date-time", props["starts_at"].Value.Format)
+		assert.Empty(t, props["clock"].Value.Format)
+		assert.Equal(t, "15:04", props["clock"].Value.Extensions["x-datetime-layout"])
+	})
+
+	t.Run("exact length", func(t *testing.T) {
+		assert.Equal(t, uint64(4), props["pin"].Value.MinLength)
+		assert.Equal(t, uintPtr(4), props["pin"].Value.MaxLength)
+	})
+
+	t.Run("numbers use values", func(t *testing.T) {
+		quantity := props["quantity"].Value
+		assert.Equal(t, floatPtr(1), quantity.Min)
+		assert.Equal(t, floatPtr(99), quantity.Max)
+		assert.Equal(t, uint64(0), quantity.MinLength)
+
+		discount := props["discount"].Value
+		assert.Equal(t, floatPtr(0), discount.Min)
+		assert.True(t, discount.ExclusiveMin)
+		assert.Equal(t, floatPtr(1), discount.Max)
+		assert.True(t, discount.ExclusiveMax)
+	})
+
+	t.Run("strings and lists use lengths", func(t *testing.T) {
+		name := props["name"].Value
+		assert.Equal(t, uint64(2), name.MinLength)
+		assert.Equal(t, uintPtr(50), name.MaxLength)
+		assert.Nil(t, name.Min)
+
+		tags := props["tags"].Value
+		assert.Equal(t, uint64(1), tags.MinItems)
+		assert.Equal(t, uintPtr(10), tags.MaxItems)
+	})
+}
+
+type PatternRequest struct {
+	Currency string `json:"currency" validate:"alphanum,len=3"`
+	Phone    string `json:"phone" validate:"required,e164"`
+	Amount   string `json:"amount" validate:"numeric"`
+	Handle   string `json:"handle" validate:"startswith=@"`
+	Domain   string `json:"domain" validate:"endswith=.com"`
+	Note     string `json:"note" validate:"contains=a.b"`
+	SKU      string `json:"sku" pattern:"^[A-Z]{3}-[0-9]{4}$" validate:"required"`
+	Count    int    `json:"count" validate:"numeric"`
+}
+
+func TestPatternConstraints(t *testing.T) {
+	app := echonext.New()
+	app.POST("/payments", func(c echo.Context, req PatternRequest) (TestUser, error) {
+		return TestUser{}, nil
+	})
+	assert.NoError(t, app.ValidateSpec())
+	props := app.GenerateOpenAPISpec().Paths["/payments"].Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties
+
+	currency := props["currency"].Value
+	assert.Equal(t, "^[a-zA-Z0-9]+$", currency.Pattern)
+	assert.Equal(t, uint64(3), currency.MinLength, "patterns coexist with bounds")
+	assert.Equal(t, `^\+[1-9]?[0-9]{7,14}$`, props["phone"].Value.Pattern)
+	assert.Equal(t, `^[-+]?[0-9]+(?:\.[0-9]+)?$`, props["amount"].Value.Pattern)
+	assert.Equal(t, "^@", props["handle"].Value.Pattern)
+	assert.Equal(t, `\.com$`, props["domain"].Value.Pattern)
+	assert.Equal(t, `a\.b`, props["note"].Value.Pattern)
+	assert.Equal(t, "^[A-Z]{3}-[0-9]{4}$", props["sku"].Value.Pattern)
+	assert.Empty(t, props["count"].Value.Pattern, "only strings get patterns")
+	assert.Nil(t, props["sku"].Value.Example, "patterned strings get no placeholder example")
+
+	// The documented patterns accept what the validator accepts
+	valid := PatternRequest{Currency: "USD", Phone: "+14155552671", Amount: "-12.50", Handle: "@ada", Domain: "example.com", Note: "xa.by", SKU: "ABC-1234"}
+	assert.NoError(t, validator.New().Struct(valid))
+	for name, value := range map[string]string{
+		"currency": valid.Currency, "phone": valid.Phone, "amount": valid.Amount,
+		"handle": valid.Handle, "domain": valid.Domain, "note": valid.Note,
+	} {
+		assert.Regexp(t, regexp.MustCompile(props[name].Value.Pattern), value, name)
+	}
+}
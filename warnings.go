@@ -0,0 +1,19 @@
+package echonext
+
+import "github.com/labstack/echo/v4"
+
+// warningsKey is the context key holding warnings added during a request
+const warningsKey = "echonext.warnings"
+
+// AddWarning records a non-fatal warning, such as use of a deprecated
+// parameter, returned in the "warnings" field of the success envelope
+func AddWarning(c echo.Context, message string) {
+	warnings, _ := c.Get(warningsKey).([]string)
+	c.Set(warningsKey, append(warnings, message))
+}
+
+// requestWarnings returns the warnings added to c
+func requestWarnings(c echo.Context) []string {
+	warnings, _ := c.Get(warningsKey).([]string)
+	return warnings
+}
@@ -0,0 +1,55 @@
+package echonext_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abdussamadbello/echonext"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	type ListRequest struct {
+		Sort string `query:"sort"`
+	}
+
+	for _, streaming := range []bool{false, true} {
+		app := echonext.New()
+		app.SetStreamingJSON(streaming)
+
+		app.GET("/users", func(c echo.Context, req ListRequest) ([]TestUser, error) {
+			if req.Sort != "" {
+				echonext.AddWarning(c, "sort is deprecated; use order")
+				echonext.AddWarning(c, "falling back to default order")
+			}
+			return []TestUser{{ID: "1", Name: "John"}}, nil
+		})
+
+		get := func(path string) (string, echonext.Response[[]TestUser]) {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response echonext.Response[[]TestUser]
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			return rec.Body.String(), response
+		}
+
+		_, response := get("/users?sort=name")
+		assert.Equal(t, []string{"sort is deprecated; use order", "falling back to default order"}, response.Warnings)
+		assert.Len(t, response.Data, 1)
+
+		body, response := get("/users")
+		assert.Nil(t, response.Warnings)
+		assert.NotContains(t, body, "warnings")
+	}
+
+	app := echonext.New()
+	app.GET("/users", func(c echo.Context) ([]TestUser, error) { return nil, nil })
+	spec := app.GenerateOpenAPISpec()
+	envelope := spec.Paths["/users"].Get.Responses["200"].Value.Content["application/json"].Schema.Value
+	assert.Equal(t, "array", envelope.Properties["warnings"].Value.Type)
+}